@@ -1,9 +1,11 @@
 package gui
 
 import (
+	"antfarm/pathfinder"
 	"antfarm/random"
 	logic "antfarm/simulation"
 	"antfarm/types"
+	"fmt"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
@@ -47,10 +49,18 @@ func (a *Antfarm) IsPaused() bool {
 
 // NewAntfarm creates and initializes a new Antfarm instance.
 // It sets up the terminal screen, creates the world sized to fit the terminal,
-// spawns an initial colony, and prepares the renderer.
+// spawns an initial colony, and prepares the renderer. mover names the
+// movement strategy every ant in the colony uses; empty keeps the default.
 //
-// Returns an error if screen initialization fails
-func NewAntfarm() (*Antfarm, error) {
+// Returns an error if mover is not a registered strategy or screen
+// initialization fails
+func NewAntfarm(mover string) (*Antfarm, error) {
+	// Check the strategy before taking over the terminal, so a typo is
+	// reported on a usable screen
+	if _, ok := pathfinder.LookupMover(mover); mover != "" && !ok {
+		return nil, fmt.Errorf("unknown movement strategy %q, want one of %v", mover, pathfinder.MoverNames())
+	}
+
 	// Initialize screen
 	screen, err := tcell.NewScreen()
 	if err != nil {
@@ -71,6 +81,12 @@ func NewAntfarm() (*Antfarm, error) {
 	queenX, queenY := width/4, height/3
 	colony := types.NewColony("Red", queenX, queenY, types.ColonyRed)
 	logic.AddColony(world, colony)
+	if mover != "" {
+		if err := useMover(colony, mover); err != nil {
+			screen.Fini()
+			return nil, err
+		}
+	}
 
 	// Create renderer
 	renderer := NewRenderer(screen)
@@ -87,6 +103,16 @@ func NewAntfarm() (*Antfarm, error) {
 	}, nil
 }
 
+// useMover has every role in a colony move by the named strategy
+func useMover(colony *types.Colony, name string) error {
+	for _, role := range []types.Role{types.Worker, types.Soldier, types.Nurse, types.Queen} {
+		if err := pathfinder.SetMover(colony, role, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Run starts the main simulation loop. This is a blocking call that runs until
 // the user quits (Q/Escape) or an error occurs.
 //
@@ -168,6 +194,12 @@ func (a *Antfarm) handleEvents(needsRender, speedChanged *bool) {
 				*needsRender = true
 			}
 
+			// Handle territory overlay toggle
+			if ev.Rune() == 't' || ev.Rune() == 'T' {
+				a.renderer.ToggleTerritory()
+				*needsRender = true
+			}
+
 			// Handle pause
 			if ev.Rune() == 'p' || ev.Rune() == 'P' {
 				a.state.paused = !a.state.paused
@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"antfarm/pathfinder"
 	"antfarm/random"
 	logic "antfarm/simulation"
 	"antfarm/types"
@@ -123,6 +124,26 @@ func TestAntfarmHandleEventsToggleLog(t *testing.T) {
 	}
 }
 
+// TestAntfarmHandleEventsToggleTerritory tests that T key toggles the territory overlay.
+func TestAntfarmHandleEventsToggleTerritory(t *testing.T) {
+	screen := mockScreen()
+	antfarm := mockAntfarm(screen)
+	antfarm.state.running = true
+
+	screen.InjectKey(tcell.KeyRune, 'T', tcell.ModNone)
+
+	needsRender := false
+	speedChanged := false
+	antfarm.handleEvents(&needsRender, &speedChanged)
+
+	if !antfarm.renderer.showTerritory {
+		t.Error("Territory overlay should have toggled on")
+	}
+	if !needsRender {
+		t.Error("needsRender should be true after toggle")
+	}
+}
+
 func TestAntfarmPause(t *testing.T) {
 	screen := mockScreen()
 	antfarm := mockAntfarm(screen)
@@ -214,3 +235,21 @@ func TestAntfarmColonyCreated(t *testing.T) {
 		t.Errorf("Expected colony name 'Red', got '%s'", antfarm.world.Colonies[0].Name)
 	}
 }
+
+// TestUseMoverSetsEveryRole tests that the -mover choice reaches every role.
+func TestUseMoverSetsEveryRole(t *testing.T) {
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+
+	if err := useMover(colony, pathfinder.AStar); err != nil {
+		t.Fatalf("Expected astar to be accepted, got %v", err)
+	}
+	for _, role := range []types.Role{types.Worker, types.Soldier, types.Nurse, types.Queen} {
+		if colony.Movers[role] != pathfinder.AStar {
+			t.Errorf("Expected %v to move by astar, got %q", role, colony.Movers[role])
+		}
+	}
+
+	if err := useMover(colony, "teleport"); err == nil {
+		t.Error("Expected an unknown strategy to be rejected")
+	}
+}
@@ -45,3 +45,63 @@ func ColonyColor(c types.ColonyColor) tcell.Color {
 		return tcell.ColorWhite
 	}
 }
+
+// InfectedColor returns the darker shade used to draw a colony's infected ants.
+func InfectedColor(c types.ColonyColor) tcell.Color {
+	switch c {
+	case types.ColonyRed:
+		return tcell.ColorMaroon
+	case types.ColonyBlue:
+		return tcell.ColorNavy
+	case types.ColonyGreen:
+		return tcell.ColorDarkGreen
+	case types.ColonyPurple:
+		return tcell.ColorIndigo
+	default:
+		return tcell.ColorGray
+	}
+}
+
+// TerritoryColor returns the background shade used to mark ground a colony holds.
+func TerritoryColor(c types.ColonyColor) tcell.Color {
+	switch c {
+	case types.ColonyRed:
+		return tcell.ColorDarkRed
+	case types.ColonyBlue:
+		return tcell.ColorDarkBlue
+	case types.ColonyGreen:
+		return tcell.ColorDarkOliveGreen
+	case types.ColonyPurple:
+		return tcell.ColorDarkMagenta
+	default:
+		return tcell.ColorDefault
+	}
+}
+
+// CritterColor returns the foreground color used to draw a non-ant creature.
+func CritterColor(kind types.CritterKind) tcell.Color {
+	switch kind {
+	case types.Aphid:
+		return tcell.ColorLime
+	case types.Spider:
+		return tcell.ColorFuchsia
+	default:
+		return tcell.ColorWhite
+	}
+}
+
+// ChamberColor returns the foreground color used to draw a chamber's floor.
+func ChamberColor(kind types.ChamberKind) tcell.Color {
+	switch kind {
+	case types.QueenChamber:
+		return tcell.ColorGold
+	case types.Nursery:
+		return tcell.ColorPink
+	case types.Granary:
+		return tcell.ColorTan
+	case types.MiddenChamber:
+		return tcell.ColorOlive
+	default:
+		return tcell.ColorWhite
+	}
+}
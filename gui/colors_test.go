@@ -45,3 +45,48 @@ func TestColonyColor(t *testing.T) {
 		}
 	}
 }
+
+func TestInfectedColor(t *testing.T) {
+	for _, colony := range []types.ColonyColor{types.ColonyRed, types.ColonyBlue, types.ColonyGreen, types.ColonyPurple} {
+		if InfectedColor(colony) == ColonyColor(colony) {
+			t.Errorf("InfectedColor(%d): infected ants should be tinted", colony)
+		}
+	}
+}
+
+func TestTerritoryColor(t *testing.T) {
+	seen := map[tcell.Color]bool{}
+	for _, colony := range []types.ColonyColor{types.ColonyRed, types.ColonyBlue, types.ColonyGreen, types.ColonyPurple} {
+		c := TerritoryColor(colony)
+		if c == ColonyColor(colony) || c == tcell.ColorDefault {
+			t.Errorf("TerritoryColor(%d): expected a shade of its own, got %v", colony, c)
+		}
+		if seen[c] {
+			t.Errorf("TerritoryColor(%d): shade %v already used by another colony", colony, c)
+		}
+		seen[c] = true
+	}
+}
+
+func TestCritterColor(t *testing.T) {
+	if CritterColor(types.Aphid) == CritterColor(types.Spider) {
+		t.Error("Aphids and spiders should be drawn in different colors")
+	}
+	if got := CritterColor(types.CritterKind(99)); got != tcell.ColorWhite {
+		t.Errorf("Unknown critter: expected white, got %v", got)
+	}
+}
+
+func TestChamberColor(t *testing.T) {
+	seen := map[tcell.Color]bool{}
+	for _, kind := range []types.ChamberKind{types.QueenChamber, types.Nursery, types.Granary, types.MiddenChamber} {
+		c := ChamberColor(kind)
+		if seen[c] {
+			t.Errorf("ChamberColor(%s): color %v already used by another chamber", kind, c)
+		}
+		seen[c] = true
+	}
+	if got := ChamberColor(types.ChamberKind(99)); got != tcell.ColorWhite {
+		t.Errorf("Unknown chamber: expected white, got %v", got)
+	}
+}
@@ -25,7 +25,7 @@ func (r *Renderer) renderControls(world *types.World, paused bool, speed float64
 	// Format speed display
 	speedStr := fmt.Sprintf("%.2fx", speed)
 
-	controls := fmt.Sprintf("[%s] Speed: %s | Q=Quit | L=Log | P=Pause | +/- =Speed", status, speedStr)
+	controls := fmt.Sprintf("[%s] Speed: %s | Q=Quit | L=Log | T=Territory | P=Pause | +/- =Speed", status, speedStr)
 
 	// Draw status with color
 	statusStyle := tcell.StyleDefault.Foreground(statusColor).Background(tcell.ColorDefault)
@@ -11,17 +11,21 @@ import (
 
 // Renderer manages drawing the simulation to the screen
 type Renderer struct {
-	screen       tcell.Screen
-	logExpanded  bool
-	maxAntsToLog int // How many ants to log
+	screen         tcell.Screen
+	logExpanded    bool
+	showTerritory  bool // Shade each cell with the colony that holds it
+	maxAntsToLog   int  // How many ants to log
+	maxEventsToLog int  // How many recent events to log
 }
 
 // NewRenderer creates a new renderer with the given screen
 func NewRenderer(screen tcell.Screen) *Renderer {
 	return &Renderer{
-		screen:       screen,
-		logExpanded:  false,
-		maxAntsToLog: 10,
+		screen:         screen,
+		logExpanded:    false,
+		showTerritory:  false,
+		maxAntsToLog:   10,
+		maxEventsToLog: 5,
 	}
 }
 
@@ -30,28 +34,6 @@ func NewRenderer(screen tcell.Screen) *Renderer {
 func (r *Renderer) Render(world *types.World, paused bool, speed float64) {
 	r.screen.Clear()
 
-	// Draw queen chambers first (background layer) --- FIX IT
-	for _, colony := range world.Colonies {
-		if colony.Queen != nil {
-			qx, qy := colony.Queen.Position.X, colony.Queen.Position.Y
-			// Draw 3x3 chamber around queen
-			for dy := -1; dy <= 1; dy++ {
-				for dx := -1; dx <= 1; dx++ {
-					x, y := qx+dx, qy+dy
-					if world.IsValidPosition(x, y) {
-						// Draw chamber walls (skip center where queen sits)
-						if dx != 0 || dy != 0 {
-							style := tcell.StyleDefault.
-								Foreground(tcell.ColorGold).
-								Background(tcell.ColorBlack)
-							r.screen.SetContent(x, y, '▢', nil, style)
-						}
-					}
-				}
-			}
-		}
-	}
-
 	// Draw the world grid (terrain and ants)
 	for y := 0; y < world.Height; y++ {
 		for x := 0; x < world.Width; x++ {
@@ -69,6 +51,9 @@ func (r *Renderer) Render(world *types.World, paused bool, speed float64) {
 				for _, colony := range world.Colonies {
 					if colony.Name == cell.Occupant.GetAnt().ColonyID {
 						fgColor = ColonyColor(colony.Color)
+						if cell.Occupant.GetAnt().IsInfected() {
+							fgColor = InfectedColor(colony.Color)
+						}
 						break
 					}
 				}
@@ -79,6 +64,16 @@ func (r *Renderer) Render(world *types.World, paused bool, speed float64) {
 				} else {
 					bgColor = SoilColor(cell.Soil, cell.IsTunnel)
 				}
+			} else if cell.Critter != nil {
+				// An aphid or spider on the surface
+				ch = cell.Critter.GetIcon()
+				fgColor = CritterColor(cell.Critter.Kind)
+				bgColor = tcell.ColorDefault
+			} else if cell.Corpse != nil {
+				// A dead ant nobody has cleared away yet
+				ch = cell.Corpse.GetIcon()
+				fgColor = tcell.ColorGray
+				bgColor = tcell.ColorDefault
 			} else {
 				// No ant - draw the terrain
 				ch = cell.GetIcon()
@@ -94,11 +89,45 @@ func (r *Renderer) Render(world *types.World, paused bool, speed float64) {
 				}
 			}
 
+			// The territory overlay shades held ground with its colony's color
+			if r.showTerritory {
+				if owner := world.TerritoryOwner(x, y); owner != nil {
+					bgColor = TerritoryColor(owner.Color)
+				}
+			}
+
 			style := tcell.StyleDefault.Foreground(fgColor).Background(bgColor)
 			r.screen.SetContent(x, y, ch, nil, style)
 		}
 	}
 
+	// Draw the floors of each colony's chambers where they have been dug out,
+	// and its midden
+	for _, colony := range world.Colonies {
+		for _, chamber := range colony.Chambers {
+			style := tcell.StyleDefault.Foreground(ChamberColor(chamber.Kind)).Background(tcell.ColorDefault)
+			for _, pos := range chamber.Cells() {
+				cell := world.GetCell(pos.X, pos.Y)
+				if cell != nil && cell.IsTunnel && cell.Occupant == nil && cell.Critter == nil && cell.Corpse == nil {
+					r.screen.SetContent(pos.X, pos.Y, chamber.GetIcon(), nil, style)
+				}
+			}
+		}
+	}
+
+	// Draw fungus gardens over their chambers, unless an ant is standing in one
+	for _, colony := range world.Colonies {
+		garden := colony.Garden
+		if garden == nil {
+			continue
+		}
+		cell := world.GetCell(garden.Position.X, garden.Position.Y)
+		if cell != nil && cell.Occupant == nil {
+			style := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDefault)
+			r.screen.SetContent(garden.Position.X, garden.Position.Y, garden.GetIcon(), nil, style)
+		}
+	}
+
 	// Draw statistics at the bottom
 	r.renderStats(world)
 	r.renderControls(world, paused, speed)
@@ -109,3 +138,8 @@ func (r *Renderer) Render(world *types.World, paused bool, speed float64) {
 func (r *Renderer) ToggleLog() {
 	r.logExpanded = !r.logExpanded
 }
+
+// ToggleTerritory toggles the territory overlay
+func (r *Renderer) ToggleTerritory() {
+	r.showTerritory = !r.showTerritory
+}
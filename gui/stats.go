@@ -1,6 +1,7 @@
 package gui
 
 import (
+	logic "antfarm/simulation"
 	"antfarm/types"
 	"fmt"
 
@@ -11,7 +12,7 @@ import (
 // Displays tick count, ant populations, food, eggs, and activity logs
 
 // renderStats displays colony information and simulation statistics
-// Shows tick count, ant populations, food, and eggs for each colony
+// Shows tick count, ant populations, food, eggs, and deaths for each colony
 func (r *Renderer) renderStats(world *types.World) {
 	y := world.Height + 1
 	style := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorDefault)
@@ -24,8 +25,35 @@ func (r *Renderer) renderStats(world *types.World) {
 
 	y++
 	for _, colony := range world.Colonies {
-		colonyStats := fmt.Sprintf("%s Colony: %d ants | Food: %d | Eggs: %d | Larvae: %d",
-			colony.Name, colony.GetAntCount(), colony.Food/types.FoodScale, colony.Eggs, len(colony.Larvae))
+		colonyStats := fmt.Sprintf("%s Colony: %d ants | Food: %d (%s) | Eggs: %d | Larvae: %d | Died: %d old age, %d starved",
+			colony.Name, colony.GetAntCount(), colony.Food.Total()/types.FoodScale, getFoodMixString(&colony.Food),
+			colony.Eggs, len(colony.Larvae),
+			colony.Deaths[types.OldAge], colony.Deaths[types.Starvation])
+		if colony.Territory > 0 {
+			colonyStats += fmt.Sprintf(" | Territory: %d", colony.Territory)
+		}
+		if herd := logic.CountHerd(world, colony); herd > 0 {
+			colonyStats += fmt.Sprintf(" | Aphids: %d", herd)
+		}
+		if sick := colony.CountInfected(); sick > 0 || colony.Deaths[types.Disease] > 0 {
+			colonyStats += fmt.Sprintf(" | Sick: %d, %d died", sick, colony.Deaths[types.Disease])
+		}
+		if corpses := logic.CountCorpsesInside(world, colony); corpses > 0 {
+			colonyStats += fmt.Sprintf(" | Corpses: %d", corpses)
+		}
+		if colony.Midden != nil {
+			colonyStats += fmt.Sprintf(" | Midden: %d at (%d,%d)", colony.Midden.Corpses,
+				colony.Midden.Center.X, colony.Midden.Center.Y)
+		}
+		if colony.Kills > 0 || colony.Deaths[types.Killed] > 0 {
+			colonyStats += fmt.Sprintf(" | Kills: %d, %d killed", colony.Kills, colony.Deaths[types.Killed])
+		}
+		if colony.Spoiled > 0 {
+			colonyStats += fmt.Sprintf(" | Spoiled: %d", colony.Spoiled/types.FoodScale)
+		}
+		if colony.Garden != nil {
+			colonyStats += fmt.Sprintf(" | Garden: %d grass", colony.Garden.Vegetation/types.FoodScale)
+		}
 		style = tcell.StyleDefault.Foreground(ColonyColor(colony.Color)).Background(tcell.ColorDefault)
 		for i, ch := range colonyStats {
 			r.screen.SetContent(i, y, ch, nil, style)
@@ -53,11 +81,20 @@ func (r *Renderer) renderStats(world *types.World) {
 	}
 }
 
-// renderActivityLog displays the activity log for ants
+// renderActivityLog displays the latest events, then what each ant is doing
 func (r *Renderer) renderActivityLog(world *types.World, startY int) {
 	y := startY
 	antCount := 0
 
+	eventStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow).Background(tcell.ColorDefault)
+	for _, event := range recentEvents(world, r.maxEventsToLog) {
+		line := fmt.Sprintf("[%d] %s", event.Tick, event.Text)
+		for i, ch := range line {
+			r.screen.SetContent(i, y, ch, nil, eventStyle)
+		}
+		y++
+	}
+
 	for _, colony := range world.Colonies {
 		allAnts := colony.GetAllAnts()
 		for _, ant := range allAnts {
@@ -89,20 +126,42 @@ func (r *Renderer) renderActivityLog(world *types.World, startY int) {
 	}
 }
 
+// recentEvents returns up to n of the world's events, newest first
+func recentEvents(world *types.World, n int) []types.Event {
+	var events []types.Event
+	for i := len(world.Events) - 1; i >= 0 && len(events) < n; i-- {
+		events = append(events, world.Events[i])
+	}
+	return events
+}
+
 // getRoleString converts a Role enum to a display string
 func getRoleString(role types.Role) string {
-	switch role {
-	case types.Worker:
-		return "Worker"
-	case types.Soldier:
-		return "Soldier"
-	case types.Queen:
-		return "Queen"
-	case types.Nurse:
-		return "Nurse"
-	case types.Larvae:
-		return "Larvae"
+	return role.String()
+}
+
+// getFoodMixString lists how much of each food type is in a store, in displayed food
+func getFoodMixString(store *types.FoodStore) string {
+	mix := ""
+	for foodType := types.FoodType(0); foodType < types.NumFoodTypes; foodType++ {
+		if mix != "" {
+			mix += ", "
+		}
+		mix += fmt.Sprintf("%d %s", store[foodType]/types.FoodScale, getFoodTypeString(foodType))
+	}
+	return mix
+}
+
+// getFoodTypeString converts a FoodType enum to a display string
+func getFoodTypeString(foodType types.FoodType) string {
+	switch foodType {
+	case types.Carbohydrate:
+		return "carb"
+	case types.Protein:
+		return "protein"
+	case types.Sugar:
+		return "sugar"
 	default:
-		return "Unknown"
+		return "unknown"
 	}
 }
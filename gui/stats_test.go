@@ -30,3 +30,30 @@ func TestGetRoleString(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFoodMixString(t *testing.T) {
+	store := types.FoodStore{
+		types.Carbohydrate: 35 * types.FoodScale,
+		types.Protein:      15 * types.FoodScale,
+	}
+
+	result := getFoodMixString(&store)
+	expected := "35 carb, 15 protein, 0 sugar"
+	if result != expected {
+		t.Errorf("getFoodMixString() = %s; want %s", result, expected)
+	}
+}
+
+func TestRecentEvents(t *testing.T) {
+	world := &types.World{
+		Events: []types.Event{{Tick: 1, Text: "a"}, {Tick: 2, Text: "b"}, {Tick: 3, Text: "c"}},
+	}
+
+	got := recentEvents(world, 2)
+	if len(got) != 2 || got[0].Text != "c" || got[1].Text != "b" {
+		t.Errorf("Expected the two newest events newest first, got %v", got)
+	}
+	if got := recentEvents(&types.World{}, 5); len(got) != 0 {
+		t.Errorf("Expected no events, got %v", got)
+	}
+}
@@ -2,12 +2,22 @@ package main
 
 import (
 	"antfarm/gui"
+	"antfarm/pathfinder"
+	"flag"
 	"log"
+	"strings"
 )
 
 func main() {
+	mover := flag.String("mover", "", "movement strategy for every ant: "+strings.Join(pathfinder.MoverNames(), ", ")+" (default "+pathfinder.DefaultMover+")")
+	drift := flag.Int("drift", pathfinder.HomeVectorDrift, "cells a worker walks for each cell of error in its way home, 0 for none")
+	flag.Parse()
+	if *drift < 0 {
+		log.Fatalf("-drift must not be negative, got %d", *drift)
+	}
+	pathfinder.HomeVectorDrift = *drift
 
-	antfarm, err := gui.NewAntfarm()
+	antfarm, err := gui.NewAntfarm(*mover)
 	if err != nil {
 		log.Fatalf("Failed to create antfarm: %v", err)
 	}
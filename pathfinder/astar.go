@@ -0,0 +1,299 @@
+package pathfinder
+
+import (
+	"antfarm/types"
+	"antfarm/util"
+	"container/heap"
+	"math"
+)
+
+// astar.go - Route planning across the grid
+// FindPath runs an A* search where open tunnel is cheap and digging costs more
+// the harder the soil. Rock and cells something is standing in are blocked.
+// StepAlong keeps each ant's route in its world's Routes, so it is only
+// planned again when the ant wants to go somewhere else, its way is blocked,
+// or a tunnel has been dug or filled on or beside the cells it has still to
+// walk (a new tunnel may be a shortcut). Edits elsewhere leave it alone.
+
+// Route costs
+var (
+	tunnelCost = 1 // Cost of stepping into open tunnel
+
+	// digCost is the cost of digging into a cell of each soil type and
+	// stepping into it. 0 means it cannot be dug at all
+	digCost = [...]int{
+		types.Sand:  3,
+		types.Dirt:  4,
+		types.Clay:  6,
+		types.Rock:  0,
+		types.Empty: 2,
+	}
+
+	routeExpiry = 100 // Ticks a route can go unused before it is forgotten
+)
+
+// stepCost returns the cost of stepping into a cell, or -1 if it is blocked
+// The goal is never blocked by whatever is standing in it, so a route can be
+// planned up to an ant or a queen
+func stepCost(world *types.World, x, y int, goal types.Position) int {
+	cell := world.GetCell(x, y)
+	if cell == nil {
+		return -1
+	}
+	isGoal := x == goal.X && y == goal.Y
+	if !isGoal && (cell.Occupant != nil || cell.Critter != nil || cell.Corpse != nil) {
+		return -1
+	}
+	if cell.IsTunnel {
+		return tunnelCost
+	}
+	if cost := digCost[cell.Soil]; cost > 0 {
+		return cost
+	}
+	return -1
+}
+
+// FindPath plans the cheapest route from start to goal
+// Open tunnel can be crossed diagonally, but digging is cardinal only so the
+// tunnels a route leaves behind stay connected. Returns the cells to step
+// through in order, ending with goal, or nil if the goal cannot be reached.
+func FindPath(world *types.World, start, goal types.Position) []types.Position {
+	if !world.IsValidPosition(start.X, start.Y) || !world.IsValidPosition(goal.X, goal.Y) {
+		return nil
+	}
+	if start == goal {
+		return []types.Position{}
+	}
+
+	search := newSearch(world)
+	startIndex := world.Index(start.X, start.Y)
+	goalIndex := world.Index(goal.X, goal.Y)
+	search.reach(startIndex, 0, -1)
+
+	open := &routeQueue{}
+	heap.Push(open, routeNode{index: startIndex, estimate: estimateCost(start, goal)})
+	for open.Len() > 0 {
+		node := heap.Pop(open).(routeNode)
+		if node.index == goalIndex {
+			break
+		}
+		if node.cost > search.cost(node.index) {
+			continue // A cheaper way here was already expanded
+		}
+
+		x, y := node.index%world.Width, node.index/world.Width
+		for _, dir := range GetAllDirections() {
+			dx, dy := DirectionToOffset(dir)
+			nx, ny := x+dx, y+dy
+			step := stepCost(world, nx, ny, goal)
+			if step < 0 {
+				continue
+			}
+			if dx != 0 && dy != 0 && step != tunnelCost {
+				continue // Diagonal steps only through open tunnel
+			}
+
+			next := world.Index(nx, ny)
+			if newCost := node.cost + step; newCost < search.cost(next) {
+				search.reach(next, newCost, node.index)
+				heap.Push(open, routeNode{
+					index:    next,
+					cost:     newCost,
+					estimate: newCost + estimateCost(types.Position{X: nx, Y: ny}, goal),
+					order:    open.pushed,
+				})
+			}
+		}
+	}
+
+	if search.cost(goalIndex) == math.MaxInt {
+		return nil
+	}
+
+	var steps []types.Position
+	for i := goalIndex; i != startIndex; i = search.Routes.From[i] {
+		steps = append(steps, types.Position{X: i % world.Width, Y: i / world.Width})
+	}
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return steps
+}
+
+// search is one run of FindPath over the world's reused scratch grids
+type search struct {
+	*types.Routes
+}
+
+// newSearch starts a search, growing the scratch grids to fit the world if
+// they do not already
+func newSearch(world *types.World) search {
+	routes := world.Routes
+	if size := world.Width * world.Height; len(routes.Seen) != size {
+		routes.Seen = make([]int, size)
+		routes.Cost = make([]int, size)
+		routes.From = make([]int, size)
+		routes.Searches = 0
+	}
+	routes.Searches++
+	return search{routes}
+}
+
+// cost returns the cheapest known cost to a cell in this search, or
+// math.MaxInt if it has not been reached yet
+func (s search) cost(index int) int {
+	if s.Seen[index] != s.Searches {
+		return math.MaxInt
+	}
+	return s.Cost[index]
+}
+
+// reach records a cheaper way to a cell and the cell it came from
+func (s search) reach(index, cost, from int) {
+	s.Seen[index] = s.Searches
+	s.Cost[index] = cost
+	s.From[index] = from
+}
+
+// estimateCost is the A* heuristic: the number of steps to goal if every one
+// were open tunnel, which never overestimates
+func estimateCost(pos, goal types.Position) int {
+	return max(util.Abs(pos.X-goal.X), util.Abs(pos.Y-goal.Y)) * tunnelCost
+}
+
+// routeNode is a cell waiting to be expanded in the search
+type routeNode struct {
+	index    int // Cell index in the world
+	cost     int // Cost of the cheapest known way to this cell
+	estimate int // cost plus the heuristic to the goal
+	order    int // When it was queued, to break ties the same way every time
+}
+
+// routeQueue is a min-heap of routeNodes by estimate, then queue order
+type routeQueue struct {
+	nodes  []routeNode
+	pushed int
+}
+
+func (q *routeQueue) Len() int { return len(q.nodes) }
+
+func (q *routeQueue) Less(i, j int) bool {
+	if q.nodes[i].estimate != q.nodes[j].estimate {
+		return q.nodes[i].estimate < q.nodes[j].estimate
+	}
+	return q.nodes[i].order < q.nodes[j].order
+}
+
+func (q *routeQueue) Swap(i, j int) { q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i] }
+
+func (q *routeQueue) Push(x any) {
+	q.nodes = append(q.nodes, x.(routeNode))
+	q.pushed++
+}
+
+func (q *routeQueue) Pop() any {
+	last := q.nodes[len(q.nodes)-1]
+	q.nodes = q.nodes[:len(q.nodes)-1]
+	return last
+}
+
+// StepAlong moves an ant one step along its route to goal, digging where
+// the route goes through soil and getting past nestmates in the way. The
+// route is kept in world.Routes and planned again if the ant has none to
+// that goal, has been moved off it, finds the next cell blocked, or a tunnel
+// has been dug or filled on or beside it since. Falls back to StepToward if there is
+// no route at all. Returns false if the ant could not move, including when
+// the only step left is into the goal and something is standing there.
+func StepAlong(world *types.World, ant types.AntInterface, goal types.Position) bool {
+	baseAnt := ant.GetAnt()
+	sweepRoutes(world)
+
+	r := world.Routes.ByAnt[baseAnt]
+	if r == nil || !routeValid(world, r, ant, goal) {
+		r = planRoute(world, baseAnt, goal)
+	}
+	if r == nil {
+		return StepToward(world, ant, goal)
+	}
+	r.Used = world.Ticks
+	r.Digs = world.TunnelEdits // Every edit so far has been checked against it
+
+	next := r.Steps[0]
+	switch {
+	case CanMoveTo(world, next.X, next.Y):
+		Move(world, ant, next.X, next.Y)
+	case CanDigTo(world, next.X, next.Y):
+		DigAndMove(world, ant, next.X, next.Y)
+		r.Digs = world.TunnelEdits // The ant's own digging does not spoil its route
+	case MustYield(world, ant, next.X, next.Y):
+		// Stepping off the route to make way means planning it again
+		return BackOff(world, ant, next)
+	case Pass(world, ant, next.X, next.Y):
+	default:
+		return false
+	}
+	r.Steps = r.Steps[1:]
+	return true
+}
+
+// routeValid reports whether an ant can still follow a route to goal
+// A route left unused too long is stale whether or not it has been swept yet,
+// so when sweeps happen never changes where an ant goes. A nestmate in the
+// way does not spoil it if the ant can get past
+func routeValid(world *types.World, r *types.Route, ant types.AntInterface, goal types.Position) bool {
+	pos := ant.GetAnt().Position
+	if r.Goal != goal || world.Ticks-r.Used >= routeExpiry ||
+		len(r.Steps) == 0 || !IsAdjacent(pos, r.Steps[0]) || routeEdited(world, r) {
+		return false
+	}
+	next := r.Steps[0]
+	return next == goal || CanMoveTo(world, next.X, next.Y) || CanDigTo(world, next.X, next.Y) ||
+		CanGetPast(world, ant, next.X, next.Y)
+}
+
+// routeEdited reports whether a tunnel has been dug or filled on or beside
+// the cells a route still has to walk since it was last checked. If the
+// edits are too many to still be logged, the route is taken as edited
+func routeEdited(world *types.World, r *types.Route) bool {
+	cells, ok := world.EditsSince(r.Digs)
+	if !ok {
+		return true
+	}
+	for _, index := range cells {
+		edited := types.Position{X: index % world.Width, Y: index / world.Width}
+		for _, step := range r.Steps {
+			if IsAdjacentOrSame(edited, step) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// planRoute finds a fresh route for an ant and remembers it
+// Returns nil and forgets any old route if there is no way through
+func planRoute(world *types.World, ant *types.Ant, goal types.Position) *types.Route {
+	steps := FindPath(world, ant.Position, goal)
+	if len(steps) == 0 {
+		delete(world.Routes.ByAnt, ant)
+		return nil
+	}
+	r := &types.Route{Goal: goal, Steps: steps, Digs: world.TunnelEdits, Used: world.Ticks}
+	world.Routes.ByAnt[ant] = r
+	return r
+}
+
+// sweepRoutes forgets routes nobody has followed for a while, such as those
+// of ants that have died
+func sweepRoutes(world *types.World) {
+	routes := world.Routes
+	if world.Ticks-routes.LastSweep < routeExpiry {
+		return
+	}
+	routes.LastSweep = world.Ticks
+	for ant, r := range routes.ByAnt {
+		if world.Ticks-r.Used >= routeExpiry {
+			delete(routes.ByAnt, ant)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package pathfinder
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestFindPathPrefersTunnelToDigging(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	world.GetCell(5, 5).IsTunnel = true
+	world.GetCell(5, 9).IsTunnel = true
+	for y := 5; y <= 9; y++ {
+		world.GetCell(6, y).IsTunnel = true
+	}
+
+	path := FindPath(world, types.Position{X: 5, Y: 5}, types.Position{X: 5, Y: 9})
+
+	if len(path) != 4 {
+		t.Fatalf("Expected a 4 step route down the side tunnel, got %v", path)
+	}
+	for _, pos := range path {
+		if !world.GetCell(pos.X, pos.Y).IsTunnel {
+			t.Errorf("Route should stay in open tunnel, digs at (%d,%d)", pos.X, pos.Y)
+		}
+	}
+	if path[len(path)-1] != (types.Position{X: 5, Y: 9}) {
+		t.Errorf("Route should end at the goal, ends at %v", path[len(path)-1])
+	}
+}
+
+func TestFindPathDigsAroundRock(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	for y := 3; y <= 8; y++ {
+		world.GetCell(8, y).Soil = types.Rock
+	}
+
+	path := FindPath(world, types.Position{X: 5, Y: 5}, types.Position{X: 11, Y: 5})
+
+	if path == nil {
+		t.Fatal("Expected a route around the rock")
+	}
+	prev := types.Position{X: 5, Y: 5}
+	for _, pos := range path {
+		if world.GetCell(pos.X, pos.Y).Soil == types.Rock {
+			t.Errorf("Route goes through rock at (%d,%d)", pos.X, pos.Y)
+		}
+		if !IsAdjacent(prev, pos) {
+			t.Errorf("Route jumps from %v to %v", prev, pos)
+		}
+		prev = pos
+	}
+}
+
+func TestFindPathUnreachable(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	for _, dir := range GetAllDirections() {
+		dx, dy := DirectionToOffset(dir)
+		world.GetCell(10+dx, 10+dy).Soil = types.Rock
+	}
+
+	if path := FindPath(world, types.Position{X: 5, Y: 5}, types.Position{X: 10, Y: 10}); path != nil {
+		t.Errorf("A goal walled in by rock should be unreachable, got %v", path)
+	}
+}
+
+func TestFindPathAvoidsAntsButNotTheGoal(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	for x := 2; x <= 10; x++ {
+		world.GetCell(x, 5).IsTunnel = true
+	}
+	world.GetCell(6, 5).Occupant = types.NewWorker(1, 6, 5, "Red")
+	world.GetCell(10, 5).Occupant = types.NewQueen(2, 10, 5, "Red")
+
+	path := FindPath(world, types.Position{X: 2, Y: 5}, types.Position{X: 10, Y: 5})
+
+	if path == nil {
+		t.Fatal("Expected a route to the queen")
+	}
+	for _, pos := range path {
+		if pos == (types.Position{X: 6, Y: 5}) {
+			t.Error("Route should go around the worker in the way")
+		}
+	}
+	if path[len(path)-1] != (types.Position{X: 10, Y: 5}) {
+		t.Errorf("Route should end at the queen, ends at %v", path[len(path)-1])
+	}
+}
+
+func TestStepAlongFollowsRoute(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	for y := 5; y <= 9; y++ {
+		world.GetCell(6, y).IsTunnel = true
+	}
+	world.GetCell(5, 5).IsTunnel = true
+	world.GetCell(5, 9).IsTunnel = true
+	worker := types.NewWorker(1, 5, 5, "Red")
+	world.GetCell(5, 5).Occupant = worker
+	goal := types.Position{X: 5, Y: 9}
+
+	for i := 0; i < 4; i++ {
+		if !StepAlong(world, worker, goal) {
+			t.Fatalf("Step %d: worker should be able to follow its route", i)
+		}
+	}
+
+	if worker.Position != goal {
+		t.Errorf("Worker should have arrived at %v, got %v", goal, worker.Position)
+	}
+	if world.TunnelEdits != 0 {
+		t.Errorf("Worker should not have dug, %d digs", world.TunnelEdits)
+	}
+}
+
+func TestStepAlongReplansAfterDig(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	world.GetCell(5, 5).IsTunnel = true
+	worker := types.NewWorker(1, 5, 5, "Red")
+	world.GetCell(5, 5).Occupant = worker
+	goal := types.Position{X: 5, Y: 12}
+
+	StepAlong(world, worker, goal)
+	first := world.Routes.ByAnt[worker.Ant]
+	if first == nil {
+		t.Fatal("The route should be remembered")
+	}
+
+	StepAlong(world, worker, goal)
+	if world.Routes.ByAnt[worker.Ant] != first {
+		t.Error("The worker's own digging should not spoil its route")
+	}
+
+	world.Dig(15, 15)
+	StepAlong(world, worker, goal)
+	if world.Routes.ByAnt[worker.Ant] != first {
+		t.Error("A dig well away from the route should not spoil it")
+	}
+
+	world.Dig(6, 10)
+	StepAlong(world, worker, goal)
+	if world.Routes.ByAnt[worker.Ant] == first {
+		t.Error("A dig beside the route should make it be planned again")
+	}
+}
+
+func TestStepAlongReplansAfterTooManyEdits(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	world.GetCell(5, 5).IsTunnel = true
+	worker := types.NewWorker(1, 5, 5, "Red")
+	world.GetCell(5, 5).Occupant = worker
+	goal := types.Position{X: 5, Y: 12}
+
+	StepAlong(world, worker, goal)
+	first := world.Routes.ByAnt[worker.Ant]
+	for i := 0; i < 300; i++ {
+		world.Dig(15, 15)
+		world.Fill(15, 15)
+	}
+
+	StepAlong(world, worker, goal)
+	if world.Routes.ByAnt[worker.Ant] == first {
+		t.Error("A route should be planned again once the edits since it was checked are no longer logged")
+	}
+}
+
+func TestFindPathReusesScratchGrids(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	for x := 2; x <= 10; x++ {
+		world.GetCell(x, 5).IsTunnel = true
+	}
+	start, goal := types.Position{X: 2, Y: 5}, types.Position{X: 10, Y: 5}
+
+	first := FindPath(world, start, goal)
+	seen := &world.Routes.Seen[0]
+	second := FindPath(world, start, goal)
+
+	if &world.Routes.Seen[0] != seen {
+		t.Error("A second search should reuse the same scratch grids")
+	}
+	if len(first) != 8 || len(second) != len(first) {
+		t.Errorf("Expected the same 8 step route twice, got %d and %d", len(first), len(second))
+	}
+}
+
+func TestStepAlongReplansAroundBlockedStep(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	for x := 2; x <= 10; x++ {
+		world.GetCell(x, 5).IsTunnel = true
+	}
+	worker := types.NewWorker(1, 2, 5, "Red")
+	world.GetCell(2, 5).Occupant = worker
+	goal := types.Position{X: 10, Y: 5}
+
+	StepAlong(world, worker, goal)
+	world.GetCell(4, 5).Occupant = types.NewWorker(2, 4, 5, "Red")
+
+	if !StepAlong(world, worker, goal) {
+		t.Fatal("Worker should find a way around the ant in its way")
+	}
+	if worker.Position == (types.Position{X: 4, Y: 5}) {
+		t.Error("Worker should not have walked into an occupied cell")
+	}
+}
@@ -0,0 +1,172 @@
+package pathfinder
+
+import (
+	"antfarm/types"
+	"fmt"
+	"sort"
+)
+
+// mover.go - Movement strategies, registered by name
+// A Mover decides how an ant gets about: how it travels toward somewhere it
+// needs to be, and how it wanders when it has nowhere in particular to go.
+// Strategies are registered by name, and each colony picks one per role, so
+// two runs on the same seed can be compared with nothing changed but the way
+// the ants move.
+
+// Mover is a movement strategy
+type Mover interface {
+	// Toward moves an ant one step toward goal. Returns false if it could not move
+	Toward(world *types.World, colony *types.Colony, ant types.AntInterface, goal types.Position) bool
+
+	// Explore moves an ant one step when it has nowhere in particular to go
+	// and sets its CurrentAction to say how. A strategy that follows trails
+	// ignores any weaker than trailThreshold. Returns false if it could not move
+	Explore(world *types.World, colony *types.Colony, ant types.AntInterface, trailThreshold int) bool
+}
+
+// Names of the built-in strategies
+const (
+	RandomWalk     = "randomwalk" // Greedy steps toward goals, wanders with momentum
+	TrailFollowing = "trail"      // Greedy steps toward goals, follows trails out when wandering
+	AStar          = "astar"      // Planned routes toward goals, follows trails out
+	FlowField      = "flowfield"  // Down the home field to the queen, planned routes elsewhere, follows trails out
+)
+
+// DefaultMover is the strategy for any role a colony has not chosen one for
+const DefaultMover = FlowField
+
+var (
+	// walker supplies the worker walking moves the strategies are built from
+	walker = NewWorkerPathfinder()
+
+	// movers holds every registered strategy by name
+	movers = map[string]Mover{
+		RandomWalk:     &greedyMover{followTrails: false},
+		TrailFollowing: &greedyMover{followTrails: true},
+		AStar:          &plannedMover{},
+		FlowField:      &flowFieldMover{},
+	}
+)
+
+// RegisterMover adds a strategy under a name, replacing any already there
+func RegisterMover(name string, mover Mover) {
+	movers[name] = mover
+}
+
+// LookupMover returns the strategy registered under a name
+func LookupMover(name string) (Mover, bool) {
+	mover, ok := movers[name]
+	return mover, ok
+}
+
+// MoverNames returns the names of every registered strategy in sorted order
+func MoverNames() []string {
+	names := make([]string, 0, len(movers))
+	for name := range movers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetMover picks the strategy a colony's ants of one role move by
+// Returns an error if no strategy is registered under that name
+func SetMover(colony *types.Colony, role types.Role, name string) error {
+	if _, ok := movers[name]; !ok {
+		return fmt.Errorf("unknown movement strategy %q", name)
+	}
+	colony.Movers[role] = name
+	return nil
+}
+
+// MoverFor returns the strategy a colony's ants of one role move by
+func MoverFor(colony *types.Colony, role types.Role) Mover {
+	if mover, ok := movers[colony.Movers[role]]; ok {
+		return mover
+	}
+	return movers[DefaultMover]
+}
+
+// greedyMover steps straight at its goal with no memory
+// Workers keep to open tunnel and detour along it before digging, and nurses
+// squeeze past the queen rather than dig around her
+type greedyMover struct {
+	followTrails bool // Follow the colony's trails out before wandering
+}
+
+func (m *greedyMover) Toward(world *types.World, colony *types.Colony, ant types.AntInterface, goal types.Position) bool {
+	if worker, ok := ant.(*types.WorkerAnt); ok {
+		return stepAlongTunnels(world, worker, goal)
+	}
+	if nurse, ok := ant.(*types.NurseAnt); ok {
+		return squeezeToward(world, colony, nurse, goal)
+	}
+	return StepToward(world, ant, goal)
+}
+
+func (m *greedyMover) Explore(world *types.World, colony *types.Colony, ant types.AntInterface, trailThreshold int) bool {
+	return (m.followTrails && followTrailOut(world, colony, ant, trailThreshold)) || wander(world, ant)
+}
+
+// plannedMover follows routes planned with A*
+type plannedMover struct{}
+
+func (m *plannedMover) Toward(world *types.World, colony *types.Colony, ant types.AntInterface, goal types.Position) bool {
+	return StepAlong(world, ant, goal)
+}
+
+func (m *plannedMover) Explore(world *types.World, colony *types.Colony, ant types.AntInterface, trailThreshold int) bool {
+	return followTrailOut(world, colony, ant, trailThreshold) || wander(world, ant)
+}
+
+// flowFieldMover walks down the colony's home field to reach the queen and
+// plans routes to anywhere else
+type flowFieldMover struct {
+	plannedMover
+}
+
+func (m *flowFieldMover) Toward(world *types.World, colony *types.Colony, ant types.AntInterface, goal types.Position) bool {
+	if goal == colony.QueenPosition && colony.HomeField != nil && StepDownhill(world, ant, colony.HomeField) {
+		return true
+	}
+	return m.plannedMover.Toward(world, colony, ant, goal)
+}
+
+// followTrailOut sends a worker out along its colony's trail
+// Only workers follow trails. Returns false if there is none at least
+// threshold strong to follow
+func followTrailOut(world *types.World, colony *types.Colony, ant types.AntInterface, threshold int) bool {
+	worker, ok := ant.(*types.WorkerAnt)
+	if !ok || !walker.FollowTrail(world, worker, colony.QueenPosition, threshold) {
+		return false
+	}
+	worker.CurrentAction = "following trail"
+	return true
+}
+
+// wander moves an ant on at random: a worker keeps going the way it was
+// heading for a few steps, anyone else takes one step into open tunnel
+func wander(world *types.World, ant types.AntInterface) bool {
+	if worker, ok := ant.(*types.WorkerAnt); ok {
+		if !walker.MoveRandomly(world, worker) {
+			return false
+		}
+		worker.CurrentAction = "exploring"
+		return true
+	}
+
+	pos := ant.GetAnt().Position
+	directions := GetAllDirections()
+	world.Random.Shuffle(len(directions), func(i, j int) {
+		directions[i], directions[j] = directions[j], directions[i]
+	})
+	for _, dir := range directions {
+		dx, dy := DirectionToOffset(dir)
+		if CanMoveTo(world, pos.X+dx, pos.Y+dy) {
+			Move(world, ant, pos.X+dx, pos.Y+dy)
+			ant.GetAnt().CurrentAction = "exploring"
+			return true
+		}
+	}
+	return false
+}
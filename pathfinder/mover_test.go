@@ -0,0 +1,124 @@
+package pathfinder
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestBuiltInMoversAreRegistered(t *testing.T) {
+	for _, name := range []string{RandomWalk, TrailFollowing, AStar, FlowField} {
+		if _, ok := LookupMover(name); !ok {
+			t.Errorf("Strategy %q should be registered", name)
+		}
+	}
+	names := MoverNames()
+	for i := 1; i < len(names); i++ {
+		if names[i-1] >= names[i] {
+			t.Errorf("MoverNames should be sorted, got %v", names)
+		}
+	}
+}
+
+func TestSetMover(t *testing.T) {
+	colony := types.NewColony("Red", 10, 10, types.ColonyRed)
+
+	if MoverFor(colony, types.Worker) != movers[DefaultMover] {
+		t.Error("A role with no strategy chosen should use the default")
+	}
+	if err := SetMover(colony, types.Worker, AStar); err != nil {
+		t.Fatalf("Setting a registered strategy should work, got %v", err)
+	}
+	if MoverFor(colony, types.Worker) != movers[AStar] {
+		t.Error("Workers should now move by A*")
+	}
+	if MoverFor(colony, types.Nurse) != movers[DefaultMover] {
+		t.Error("Choosing for workers should leave nurses on the default")
+	}
+	if err := SetMover(colony, types.Worker, "teleport"); err == nil {
+		t.Error("An unknown strategy should be an error")
+	}
+	if colony.Movers[types.Worker] != AStar {
+		t.Error("A failed choice should leave the old one in place")
+	}
+}
+
+func TestColoniesMoveByTheirOwnStrategy(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	world.GetCell(5, 5).IsTunnel = true
+	world.GetCell(5, 9).IsTunnel = true
+	for y := 5; y <= 9; y++ {
+		world.GetCell(6, y).IsTunnel = true
+	}
+	goal := types.Position{X: 5, Y: 9}
+
+	planned := types.NewColony("Red", 15, 15, types.ColonyRed)
+	SetMover(planned, types.Worker, AStar)
+	worker := types.NewWorker(1, 5, 5, "Red")
+	world.GetCell(5, 5).Occupant = worker
+	NewWorkerPathfinder().MoveTowardTarget(world, planned, worker, goal)
+	if worker.Position != (types.Position{X: 6, Y: 6}) {
+		t.Errorf("A* should take the side tunnel rather than dig, got %v", worker.Position)
+	}
+
+	greedy := types.NewColony("Blue", 15, 15, types.ColonyBlue)
+	SetMover(greedy, types.Worker, RandomWalk)
+	Move(world, worker, 5, 5)
+	worker.CurrentDirection = int(DirIdle)
+	NewWorkerPathfinder().MoveTowardTarget(world, greedy, worker, goal)
+	if worker.Position != (types.Position{X: 6, Y: 6}) && worker.Position != (types.Position{X: 5, Y: 6}) {
+		t.Errorf("Greedy should step straight at the goal, got %v", worker.Position)
+	}
+}
+
+// countingMover records how often it is asked to move an ant
+type countingMover struct {
+	toward, explore int
+}
+
+func (m *countingMover) Toward(world *types.World, colony *types.Colony, ant types.AntInterface, goal types.Position) bool {
+	m.toward++
+	return false
+}
+
+func (m *countingMover) Explore(world *types.World, colony *types.Colony, ant types.AntInterface, trailThreshold int) bool {
+	m.explore++
+	return false
+}
+
+func TestRegisteredMoverIsUsed(t *testing.T) {
+	counter := &countingMover{}
+	RegisterMover("counting", counter)
+	defer delete(movers, "counting")
+
+	world := types.NewWorld(20, 20, random.New(1))
+	colony := types.NewColony("Red", 10, 10, types.ColonyRed)
+	SetMover(colony, types.Worker, "counting")
+	worker := types.NewWorker(2, 5, 5, "Red")
+	world.GetCell(5, 5).Occupant = worker
+
+	NewWorkerPathfinder().BringFoodToQueen(world, colony, worker)
+	MoverFor(colony, types.Worker).Explore(world, colony, worker, 10)
+
+	if counter.toward != 1 || counter.explore != 1 {
+		t.Errorf("Expected one Toward and one Explore, got %d and %d", counter.toward, counter.explore)
+	}
+}
+
+func TestFlowFieldWalksDownhillToQueen(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	colony := types.NewColony("Red", 5, 10, types.ColonyRed)
+	for x := 5; x <= 10; x++ {
+		world.GetCell(x, 10).IsTunnel = true
+	}
+	colony.HomeField = types.NewDistanceField(world, colony.QueenPosition)
+	worker := types.NewWorker(2, 9, 10, "Red")
+	world.GetCell(9, 10).Occupant = worker
+
+	if !MoverFor(colony, types.Worker).Toward(world, colony, worker, colony.QueenPosition) {
+		t.Fatal("Worker should walk toward the queen")
+	}
+	if worker.Position != (types.Position{X: 8, Y: 10}) {
+		t.Errorf("Worker should step down the home field, got %v", worker.Position)
+	}
+}
@@ -38,7 +38,19 @@ func (np *NursePathfinder) MoveTowardLarvae(world *types.World, colony *types.Co
 }
 
 // MoveTowardTarget moves nurse toward a target, can pass through queen's cell
+// The nurse moves by the colony's nurse strategy first, and only falls back to
+// squeezing past the queen when that gets her nowhere
 func (np *NursePathfinder) MoveTowardTarget(world *types.World, colony *types.Colony, nurse *types.NurseAnt, target types.Position, goAroundQueen bool) bool {
+	if MoverFor(colony, types.Nurse).Toward(world, colony, nurse, target) {
+		return true
+	}
+
+	return squeezeToward(world, colony, nurse, target)
+}
+
+// squeezeToward steps a nurse greedily toward a target, swapping places with
+// the queen if she is in the way
+func squeezeToward(world *types.World, colony *types.Colony, nurse *types.NurseAnt, target types.Position) bool {
 	curX := nurse.Position.X
 	curY := nurse.Position.Y
 
@@ -66,23 +78,13 @@ func (np *NursePathfinder) MoveTowardTarget(world *types.World, colony *types.Co
 		// A queenless colony still remembers where her chamber was, so check she
 		// actually exists before trying to move her out of the way.
 		if colony.Queen != nil && newX == colony.QueenPosition.X && newY == colony.QueenPosition.Y {
-			// Swap: nurse goes to queen's spot, queen goes to nurse's spot
-			queenCell := world.GetCell(colony.QueenPosition.X, colony.QueenPosition.Y)
-			nurseCell := world.GetCell(curX, curY)
-
-			// Move queen to nurse's old position
-			colony.Queen.Position.X = curX
-			colony.Queen.Position.Y = curY
-			nurseCell.Occupant = colony.Queen
+			Swap(world, nurse, colony.Queen)
 
 			// Update colony's queen position so everyant knows
-			colony.QueenPosition.X = curX
-			colony.QueenPosition.Y = curY
-
-			// Move nurse to queen's position
-			nurse.Position.X = newX
-			nurse.Position.Y = newY
-			queenCell.Occupant = nurse
+			colony.QueenPosition = colony.Queen.Position
+			if colony.HomeField != nil {
+				colony.HomeField.Track(world, colony.QueenPosition)
+			}
 
 			return true
 		}
@@ -92,6 +94,10 @@ func (np *NursePathfinder) MoveTowardTarget(world *types.World, colony *types.Co
 			return true
 		}
 
+		if Pass(world, nurse, newX, newY) {
+			return true
+		}
+
 		if CanDigTo(world, newX, newY) {
 			DigAndMove(world, nurse, newX, newY)
 			return true
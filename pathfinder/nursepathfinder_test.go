@@ -98,6 +98,30 @@ func TestNurseMoveTowardQueen(t *testing.T) {
 	}
 }
 
+func TestNurseSwapWithQueenMovesHomeField(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	np := NewNursePathfinder()
+	colony := types.NewColony("Red", 10, 10, types.ColonyRed)
+	for x := 5; x <= 15; x++ {
+		world.GetCell(x, 10).IsTunnel = true
+	}
+	world.GetCell(10, 10).Occupant = colony.Queen
+	colony.HomeField = types.NewDistanceField(world, colony.QueenPosition)
+	SetMover(colony, types.Nurse, RandomWalk)
+	nurse := types.NewNurse(2, 9, 10, "Red")
+	world.GetCell(9, 10).Occupant = nurse
+
+	// Squeezing past the queen to reach a larvae beyond her
+	np.MoveTowardLarvae(world, colony, nurse, types.Position{X: 12, Y: 10})
+
+	if colony.QueenPosition != (types.Position{X: 9, Y: 10}) {
+		t.Fatalf("Nurse should have swapped with the queen, queen at %v", colony.QueenPosition)
+	}
+	if colony.HomeField.At(9, 10) != 0 {
+		t.Error("Home field should be measured from the queen's new cell")
+	}
+}
+
 func TestNurseMoveTowardLarvae(t *testing.T) {
 	world := types.NewWorld(20, 20, random.New(1))
 	np := NewNursePathfinder()
@@ -79,7 +79,7 @@ func CanMoveTo(world *types.World, x, y int) bool {
 	if cell == nil {
 		return false
 	}
-	return cell.IsTunnel && cell.Occupant == nil
+	return cell.IsTunnel && cell.Occupant == nil && cell.Critter == nil && cell.Corpse == nil
 }
 
 // CanDigTo checks if a cell can be dug into
@@ -91,17 +91,127 @@ func CanDigTo(world *types.World, x, y int) bool {
 	return !cell.IsTunnel && cell.Soil != types.Rock
 }
 
+// StepToward moves any ant one step toward a target
+// Neighbours are tried in three bands: those that close the distance, those
+// that keep it, and those that lose ground. Within a band open tunnel wins
+// over digging, and in the first band getting past a nestmate comes between
+// the two. Sidestepping before retreating lets the ant work around an
+// obstacle instead of bouncing between two open cells in front of it.
+func StepToward(world *types.World, ant types.AntInterface, target types.Position) bool {
+	pos := ant.GetAnt().Position
+	currentDist := ManhattanDistance(pos, target)
+
+	var closer, level, away []Direction
+	for _, dir := range GetAllDirections() {
+		dx, dy := DirectionToOffset(dir)
+		newDist := ManhattanDistance(types.Position{X: pos.X + dx, Y: pos.Y + dy}, target)
+		switch {
+		case newDist < currentDist:
+			closer = append(closer, dir)
+		case newDist == currentDist:
+			level = append(level, dir)
+		default:
+			away = append(away, dir)
+		}
+	}
+
+	for i, band := range [][]Direction{closer, level, away} {
+		// First pass: try empty tunnels
+		for _, dir := range band {
+			dx, dy := DirectionToOffset(dir)
+			if CanMoveTo(world, pos.X+dx, pos.Y+dy) {
+				Move(world, ant, pos.X+dx, pos.Y+dy)
+				return true
+			}
+		}
+
+		// Then get past a nestmate standing in the way
+		if i == 0 {
+			for _, dir := range band {
+				dx, dy := DirectionToOffset(dir)
+				if Pass(world, ant, pos.X+dx, pos.Y+dy) {
+					return true
+				}
+			}
+		}
+
+		// Last pass: dig if needed
+		for _, dir := range band {
+			dx, dy := DirectionToOffset(dir)
+			if CanDigTo(world, pos.X+dx, pos.Y+dy) {
+				DigAndMove(world, ant, pos.X+dx, pos.Y+dy)
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// StepDownhill moves an ant to the free neighbour nearest home on a distance
+// field, if it is nearer home than where the ant stands. Ties go to the first
+// neighbour in direction order. If every way nearer home is taken the ant
+// tries to get past a nestmate instead. Returns false if the ant is off the
+// field or could not move
+func StepDownhill(world *types.World, ant types.AntInterface, field *types.DistanceField) bool {
+	pos := ant.GetAnt().Position
+	best := field.At(pos.X, pos.Y)
+	if best == types.Unreachable {
+		return false
+	}
+
+	bestDir := DirIdle
+	blockedDir := DirIdle
+	blocked := best
+	for _, dir := range GetAllDirections() {
+		dx, dy := DirectionToOffset(dir)
+		d := field.At(pos.X+dx, pos.Y+dy)
+		if d < best && CanMoveTo(world, pos.X+dx, pos.Y+dy) {
+			best = d
+			bestDir = dir
+		}
+		if d < blocked && CanGetPast(world, ant, pos.X+dx, pos.Y+dy) {
+			blocked = d
+			blockedDir = dir
+		}
+	}
+	if bestDir == DirIdle {
+		// Every way down is taken, so get past a nestmate on it if possible
+		if blockedDir == DirIdle {
+			return false
+		}
+		dx, dy := DirectionToOffset(blockedDir)
+		return Pass(world, ant, pos.X+dx, pos.Y+dy)
+	}
+
+	dx, dy := DirectionToOffset(bestDir)
+	Move(world, ant, pos.X+dx, pos.Y+dy)
+	return true
+}
+
 // Move relocates an ant from its current position to a new position
+// While the world's moves are pending the step is only declared, and is made
+// when CommitMoves settles the tick's traffic
 func Move(world *types.World, ant types.AntInterface, newX, newY int) {
+	if world.Traffic != nil && world.Traffic.Pending {
+		world.Traffic.Declare(ant, types.Position{X: newX, Y: newY})
+		return
+	}
+	relocate(world, ant, newX, newY)
+}
+
+// relocate moves an ant at once
+func relocate(world *types.World, ant types.AntInterface, newX, newY int) {
 	baseAnt := ant.GetAnt()
 
-	// Clear old position
+	// Clear old position, unless someone else has already taken it
 	oldCell := world.GetCell(baseAnt.Position.X, baseAnt.Position.Y)
-	if oldCell != nil {
+	if oldCell != nil && oldCell.Occupant == ant {
 		oldCell.Occupant = nil
 	}
 
 	// Move to new position
+	baseAnt.Heading = types.Position{X: newX - baseAnt.Position.X, Y: newY - baseAnt.Position.Y}
 	baseAnt.Position.X = newX
 	baseAnt.Position.Y = newY
 
@@ -118,7 +228,7 @@ func DigAndMove(world *types.World, ant types.AntInterface, newX, newY int) bool
 		return false
 	}
 	if !cell.IsTunnel && cell.Soil != types.Rock {
-		cell.IsTunnel = true
+		world.Dig(newX, newY)
 		// Reduce health for each dig
 		baseAnt := ant.GetAnt()
 		baseAnt.Health--
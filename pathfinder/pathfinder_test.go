@@ -223,3 +223,94 @@ func TestDigAndMoveFail(t *testing.T) {
 		t.Error("DigAndMove should fail on rock")
 	}
 }
+
+func TestStepDownhill(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	for x := 5; x <= 10; x++ {
+		world.GetCell(x, 10).IsTunnel = true
+		world.GetCell(x, 11).IsTunnel = true
+	}
+	field := types.NewDistanceField(world, types.Position{X: 5, Y: 10})
+	worker := types.NewWorker(1, 9, 10, "Red")
+	world.GetCell(9, 10).Occupant = worker
+
+	if !StepDownhill(world, worker, field) {
+		t.Fatal("Worker should step toward home")
+	}
+	if worker.Position.X != 8 {
+		t.Errorf("Worker should be one step nearer home, got %v", worker.Position)
+	}
+
+	// With the way along the top blocked it takes the lower tunnel
+	world.GetCell(7, 10).Occupant = types.NewWorker(2, 7, 10, "Red")
+	if !StepDownhill(world, worker, field) || worker.Position != (types.Position{X: 7, Y: 11}) {
+		t.Errorf("Worker should go round the blocked cell, got %v", worker.Position)
+	}
+}
+
+func TestStepDownhillOffField(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	world.GetCell(5, 10).IsTunnel = true
+	field := types.NewDistanceField(world, types.Position{X: 5, Y: 10})
+	worker := types.NewWorker(1, 12, 12, "Red")
+	world.GetCell(12, 12).IsTunnel = true
+	world.GetCell(12, 12).Occupant = worker
+
+	if StepDownhill(world, worker, field) {
+		t.Error("A worker off the field has no way downhill")
+	}
+}
+
+// stepTowardWorld puts a worker at (5,10) heading for (10,10), with rock on
+// the given cells and open tunnel on the others listed
+func stepTowardWorld(rock, open []types.Position) (*types.World, *types.WorkerAnt) {
+	world := types.NewWorld(20, 20, random.New(1))
+	for _, pos := range rock {
+		world.GetCell(pos.X, pos.Y).Soil = types.Rock
+	}
+	for _, pos := range open {
+		world.GetCell(pos.X, pos.Y).IsTunnel = true
+	}
+	worker := types.NewWorker(1, 5, 10, "Red")
+	world.GetCell(5, 10).IsTunnel = true
+	world.GetCell(5, 10).Occupant = worker
+	return world, worker
+}
+
+func TestStepTowardSidestepsBeforeRetreating(t *testing.T) {
+	world, worker := stepTowardWorld(
+		[]types.Position{{X: 6, Y: 10}},
+		[]types.Position{{X: 4, Y: 10}, {X: 6, Y: 11}},
+	)
+
+	if !StepToward(world, worker, types.Position{X: 10, Y: 10}) {
+		t.Fatal("Worker should find a way to step")
+	}
+	if worker.Position != (types.Position{X: 6, Y: 11}) {
+		t.Errorf("Worker should sidestep round the rock, not back off, got %v", worker.Position)
+	}
+}
+
+func TestStepTowardDigsCloserBeforeSidestepping(t *testing.T) {
+	world, worker := stepTowardWorld(nil, []types.Position{{X: 6, Y: 11}})
+
+	StepToward(world, worker, types.Position{X: 10, Y: 10})
+
+	if worker.Position != (types.Position{X: 6, Y: 10}) || !world.GetCell(6, 10).IsTunnel {
+		t.Errorf("Worker should dig straight toward the target, got %v", worker.Position)
+	}
+}
+
+func TestStepTowardRetreatsOnlyWhenBoxedIn(t *testing.T) {
+	world, worker := stepTowardWorld(
+		[]types.Position{{X: 6, Y: 9}, {X: 6, Y: 10}, {X: 6, Y: 11}},
+		[]types.Position{{X: 4, Y: 10}},
+	)
+
+	if !StepToward(world, worker, types.Position{X: 10, Y: 10}) {
+		t.Fatal("Worker should back off when every way forward is rock")
+	}
+	if worker.Position != (types.Position{X: 4, Y: 10}) {
+		t.Errorf("Worker should take the open way back, got %v", worker.Position)
+	}
+}
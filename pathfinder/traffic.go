@@ -0,0 +1,82 @@
+package pathfinder
+
+import (
+	"antfarm/types"
+)
+
+// traffic.go - Settling the tick's moves all at once
+// Between BeginMoves and CommitMoves every Move is only declared. The moves
+// are then settled in an order shuffled by the world's random source, so no
+// ant gets a contested cell just for being near the front of its colony's
+// list. A move into a cell someone is standing in goes ahead if they are
+// moving out too, so a file of ants can shuffle along a tunnel together and
+// two ants can swap places.
+
+// BeginMoves starts a tick in which moves are declared rather than made
+func BeginMoves(world *types.World) {
+	world.Traffic.Pending = true
+}
+
+// CommitMoves settles every move declared since BeginMoves and makes them
+// The first move into a cell gets it. Moves turned back, for that or because
+// the cell's occupant stayed put, are counted in the cell's jams
+func CommitMoves(world *types.World) {
+	traffic := world.Traffic
+	traffic.Pending = false
+	intents := traffic.Take()
+	world.Random.Shuffle(len(intents), func(i, j int) {
+		intents[i], intents[j] = intents[j], intents[i]
+	})
+
+	live := make([]bool, len(intents))
+	claimed := map[int]bool{}
+	leaving := map[int]int{} // Cell index to the move of the ant leaving it
+	for i, move := range intents {
+		from := world.GetCell(move.From.X, move.From.Y)
+		if move.Ant.GetAnt().Position != move.From || from == nil || from.Occupant != move.Ant || move.To == move.From {
+			continue // Moved, removed or going nowhere since it declared
+		}
+		to := world.GetCell(move.To.X, move.To.Y)
+		if to == nil {
+			continue
+		}
+		target := world.Index(move.To.X, move.To.Y)
+		if !to.IsTunnel || to.Critter != nil || to.Corpse != nil || claimed[target] {
+			traffic.Jams[target]++
+			continue
+		}
+		claimed[target] = true
+		live[i] = true
+		leaving[world.Index(move.From.X, move.From.Y)] = i
+	}
+
+	// A move into an occupied cell needs the occupant to get out of the way.
+	// Turning one move back can strand the ant behind it, so go round until
+	// nothing changes
+	for changed := true; changed; {
+		changed = false
+		for i, move := range intents {
+			if !live[i] || world.GetCell(move.To.X, move.To.Y).Occupant == nil {
+				continue
+			}
+			target := world.Index(move.To.X, move.To.Y)
+			if j, ok := leaving[target]; ok && live[j] {
+				continue
+			}
+			live[i] = false
+			delete(leaving, world.Index(move.From.X, move.From.Y))
+			traffic.Jams[target]++
+			changed = true
+		}
+	}
+
+	// relocate only clears a cell its ant still holds, so the moves can be
+	// made one at a time without one ant wiping out another
+	for i, move := range intents {
+		if live[i] {
+			relocate(world, move.Ant, move.To.X, move.To.Y)
+		} else {
+			move.Ant.GetAnt().Heading = types.Position{X: 0, Y: 0} // Held up, so not going anywhere
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package pathfinder
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestContestedCellGoesToOneAnt(t *testing.T) {
+	world := corridor()
+	a := types.NewWorker(1, 5, 10, "Red")
+	b := types.NewWorker(2, 7, 10, "Red")
+	place(world, a)
+	place(world, b)
+
+	BeginMoves(world)
+	Move(world, a, 6, 10)
+	Move(world, b, 6, 10)
+	if a.Position.X != 5 || b.Position.X != 7 {
+		t.Fatal("Declared moves should not be made until they are committed")
+	}
+	CommitMoves(world)
+
+	if (a.Position.X == 6) == (b.Position.X == 6) {
+		t.Fatalf("Exactly one ant should get the cell, got %v and %v", a.Position, b.Position)
+	}
+	if world.JamsAt(6, 10) != 1 {
+		t.Errorf("The losing move should be counted as a jam, got %d", world.JamsAt(6, 10))
+	}
+	if world.Traffic.Pending || len(world.Traffic.Intents) != 0 {
+		t.Error("Committing should clear the tick's moves")
+	}
+}
+
+func TestContestsAreNotWonByOrder(t *testing.T) {
+	firstWins := 0
+	for seed := uint32(1); seed <= 40; seed++ {
+		world := types.NewWorld(20, 20, random.New(seed))
+		for x := 4; x <= 8; x++ {
+			world.GetCell(x, 10).IsTunnel = true
+		}
+		a := types.NewWorker(1, 5, 10, "Red")
+		b := types.NewWorker(2, 7, 10, "Red")
+		place(world, a)
+		place(world, b)
+
+		BeginMoves(world)
+		Move(world, a, 6, 10)
+		Move(world, b, 6, 10)
+		CommitMoves(world)
+		if a.Position.X == 6 {
+			firstWins++
+		}
+	}
+
+	if firstWins < 10 || firstWins > 30 {
+		t.Errorf("The first ant to declare won %d of 40 contests", firstWins)
+	}
+}
+
+func TestFileOfAntsMovesTogether(t *testing.T) {
+	world := corridor()
+	var file []*types.WorkerAnt
+	for x := 5; x <= 7; x++ {
+		ant := types.NewWorker(x, x, 10, "Red")
+		place(world, ant)
+		file = append(file, ant)
+	}
+
+	BeginMoves(world)
+	for _, ant := range file {
+		Move(world, ant, ant.Position.X+1, 10)
+	}
+	CommitMoves(world)
+
+	for i, ant := range file {
+		if ant.Position.X != 6+i {
+			t.Errorf("Ant %d should have stepped on to %d, got %v", i, 6+i, ant.Position)
+		}
+		if world.GetCell(6+i, 10).Occupant != ant {
+			t.Errorf("Cell %d should hold ant %d", 6+i, i)
+		}
+	}
+	if world.GetCell(5, 10).Occupant != nil {
+		t.Error("The cell at the back should be empty")
+	}
+}
+
+func TestDeclaredSwapIsMade(t *testing.T) {
+	world := corridor()
+	a := types.NewWorker(1, 5, 10, "Red")
+	a.CarryingFood = true
+	b := types.NewWorker(2, 6, 10, "Red")
+	place(world, a)
+	place(world, b)
+
+	BeginMoves(world)
+	if !Pass(world, a, 6, 10) {
+		t.Fatal("Loaded ant should declare a swap")
+	}
+	CommitMoves(world)
+
+	if a.Position.X != 6 || b.Position.X != 5 {
+		t.Errorf("Expected the two to swap, got %v and %v", a.Position, b.Position)
+	}
+}
+
+func TestMoveIntoAntThatStaysIsJammed(t *testing.T) {
+	world := corridor()
+	a := types.NewWorker(1, 5, 10, "Red")
+	b := types.NewWorker(2, 6, 10, "Red")
+	b.Heading = types.Position{X: 1, Y: 0}
+	place(world, a)
+	place(world, b)
+
+	BeginMoves(world)
+	if !Pass(world, a, 6, 10) {
+		t.Fatal("Worker should fall in behind a nestmate going its way")
+	}
+	CommitMoves(world)
+
+	if a.Position.X != 5 || world.GetCell(6, 10).Occupant != b {
+		t.Errorf("Worker should be held up by the nestmate that stayed, got %v", a.Position)
+	}
+	if world.JamsAt(6, 10) != 1 {
+		t.Errorf("Expected one jam at (6,10), got %d", world.JamsAt(6, 10))
+	}
+	if a.Heading != (types.Position{}) {
+		t.Errorf("A held up ant is going nowhere, heading %v", a.Heading)
+	}
+}
+
+func TestRemovedAntIsNotMoved(t *testing.T) {
+	world := corridor()
+	a := types.NewWorker(1, 5, 10, "Red")
+	place(world, a)
+
+	BeginMoves(world)
+	Move(world, a, 6, 10)
+	world.GetCell(5, 10).Occupant = nil // Died before the moves were made
+	CommitMoves(world)
+
+	if world.GetCell(6, 10).Occupant != nil {
+		t.Error("An ant taken off the grid should not be put back by its move")
+	}
+}
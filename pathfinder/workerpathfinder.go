@@ -2,6 +2,7 @@ package pathfinder
 
 import (
 	"antfarm/types"
+	"antfarm/util"
 )
 
 // WorkerPathfinder handles movement logic for worker ants
@@ -43,6 +44,77 @@ func (wp *WorkerPathfinder) MoveRandomly(world *types.World, worker *types.Worke
 	return wp.pickNewDirection(world, worker)
 }
 
+// FollowScent steps the worker up the scent gradient of the listed food types,
+// digging toward the smell if it has to. Only the four cardinal neighbours are
+// sensed so any tunnel it digs stays connected. Returns false if nothing
+// nearby smells stronger than where the worker already is.
+func (wp *WorkerPathfinder) FollowScent(world *types.World, worker *types.WorkerAnt, diet ...types.FoodType) bool {
+	pos := worker.Position
+
+	bestDir := DirIdle
+	bestLevel := world.ScentOf(pos.X, pos.Y, diet...)
+	for _, dir := range GetCardinalDirections() {
+		dx, dy := DirectionToOffset(dir)
+		newX, newY := pos.X+dx, pos.Y+dy
+		if !CanMoveTo(world, newX, newY) && !CanDigTo(world, newX, newY) {
+			continue
+		}
+		if level := world.ScentOf(newX, newY, diet...); level > bestLevel {
+			bestDir = dir
+			bestLevel = level
+		}
+	}
+	if bestDir == DirIdle {
+		return false
+	}
+
+	dx, dy := DirectionToOffset(bestDir)
+	if CanMoveTo(world, pos.X+dx, pos.Y+dy) {
+		Move(world, worker, pos.X+dx, pos.Y+dy)
+	} else {
+		DigAndMove(world, worker, pos.X+dx, pos.Y+dy)
+	}
+	worker.CurrentDirection = int(bestDir)
+	worker.MovesMade = worker.MovesInDirection
+	return true
+}
+
+// FollowTrail steps the worker along its colony's recruitment trail, away from
+// home and so toward whatever food the trail was laid from. Of the open
+// neighbours that take it further from home it picks the one with the most
+// trail, and only if that reaches threshold. Returns false if there is no
+// trail to follow, so the caller can fall back to exploring.
+func (wp *WorkerPathfinder) FollowTrail(world *types.World, worker *types.WorkerAnt, home types.Position, threshold int) bool {
+	pos := worker.Position
+	dist := ManhattanDistance(pos, home)
+
+	bestDir := DirIdle
+	bestLevel := threshold - 1
+	for _, dir := range GetAllDirections() {
+		dx, dy := DirectionToOffset(dir)
+		newX, newY := pos.X+dx, pos.Y+dy
+		if ManhattanDistance(types.Position{X: newX, Y: newY}, home) <= dist || !CanMoveTo(world, newX, newY) {
+			continue
+		}
+		if level := world.Pheromones.Level(worker.ColonyID, types.Trail, newX, newY); level > bestLevel {
+			bestDir = dir
+			bestLevel = level
+		}
+	}
+	if bestDir == DirIdle {
+		return false
+	}
+
+	dx, dy := DirectionToOffset(bestDir)
+	Move(world, worker, pos.X+dx, pos.Y+dy)
+
+	// Whatever momentum the worker had is spent; it picks a fresh
+	// direction once it runs off the end of the trail
+	worker.CurrentDirection = int(bestDir)
+	worker.MovesMade = worker.MovesInDirection
+	return true
+}
+
 // pickNewDirection selects a new random direction for the worker
 func (wp *WorkerPathfinder) pickNewDirection(world *types.World, worker *types.WorkerAnt) bool {
 	// Get all cardinal directions
@@ -117,124 +189,122 @@ func (wp *WorkerPathfinder) getOppositeDirection(dir Direction) Direction {
 	}
 }
 
-// BringFoodToQueen moves the worker toward the queen to deposit food
+// BringFoodToQueen carries the worker home by the colony's worker strategy
+// Out on the surface it follows its path-integration vector: it heads for
+// where it reckons home is rather than for the stores themselves, so a worker
+// that has wandered far comes back a little off course. Once it is down in
+// the colony's tunnels, or the vector has run out, it is close enough to
+// smell the nest and makes for the stores directly, which are with the queen
+// until the colony has dug its granary.
 func (wp *WorkerPathfinder) BringFoodToQueen(world *types.World, colony *types.Colony, worker *types.WorkerAnt) bool {
-	target := colony.QueenPosition
-
-	// Current position
-	curX := worker.Position.X
-	curY := worker.Position.Y
-
-	// Build directions based on where we need to go
-	var directions [][2]int
-
-	// Add directions that move us closer first
-	if curX < target.X {
-		directions = append(directions, [2]int{1, 0}) // Right
-	} else if curX > target.X {
-		directions = append(directions, [2]int{-1, 0}) // Left
+	target := colony.StorePosition()
+	if reckoned := worker.ReckonedHome(); !underground(world, worker.Position) && !IsAdjacentOrSame(worker.Position, reckoned) {
+		target = reckoned
 	}
+	return MoverFor(colony, types.Worker).Toward(world, colony, worker, target)
+}
 
-	if curY < target.Y {
-		directions = append(directions, [2]int{0, 1}) // Down
-	} else if curY > target.Y {
-		directions = append(directions, [2]int{0, -1}) // Up
-	}
+// underground reports whether a position is below the open surface
+// The surface is the only ground with no soil at all; a dug tunnel keeps the
+// soil it was dug through
+func underground(world *types.World, pos types.Position) bool {
+	cell := world.GetCell(pos.X, pos.Y)
+	return cell != nil && cell.Soil != types.Empty
+}
 
-	// Add perpendicular directions for going around obstacles
-	if curX == target.X {
-		// On same X, add left/right for going around
-		directions = append(directions, [2]int{1, 0}, [2]int{-1, 0})
-	}
-	if curY == target.Y {
-		// On same Y, add up/down for going around
-		directions = append(directions, [2]int{0, 1}, [2]int{0, -1})
+// stepAlongTunnels moves a worker one step toward target, keeping to open tunnel
+// It takes open tunnel that closes the distance first, then detours sideways
+// along open tunnel, then tries to get past a nestmate in the way, and only
+// digs when none of those is possible. Digging is cardinal only so the
+// tunnels it leaves stay connected. The worker only steps straight back the
+// way it came when it is hemmed in, so a detour cannot bounce.
+func stepAlongTunnels(world *types.World, worker *types.WorkerAnt, target types.Position) bool {
+	pos := worker.Position
+	dist := ManhattanDistance(pos, target)
+	lastDx, lastDy := DirectionToOffset(Direction(worker.CurrentDirection))
+
+	var closer, level []Direction
+	for _, dir := range GetAllDirections() {
+		dx, dy := DirectionToOffset(dir)
+		switch newDist := ManhattanDistance(types.Position{X: pos.X + dx, Y: pos.Y + dy}, target); {
+		case newDist < dist:
+			closer = append(closer, dir)
+		case newDist == dist && (dx != -lastDx || dy != -lastDy):
+			level = append(level, dir)
+		}
 	}
 
-	// Try each direction
-	for _, dir := range directions {
-		newX := curX + dir[0]
-		newY := curY + dir[1]
-
-		// Don't step on queen
-		if newX == target.X && newY == target.Y {
-			continue
+	for _, band := range [][]Direction{closer, level} {
+		for _, dir := range band {
+			dx, dy := DirectionToOffset(dir)
+			if CanMoveTo(world, pos.X+dx, pos.Y+dy) {
+				Move(world, worker, pos.X+dx, pos.Y+dy)
+				headed(worker, dir)
+				return true
+			}
 		}
+	}
 
-		// Try to move
-		if CanMoveTo(world, newX, newY) {
-			Move(world, worker, newX, newY)
+	for _, dir := range closer {
+		dx, dy := DirectionToOffset(dir)
+		if Pass(world, worker, pos.X+dx, pos.Y+dy) {
 			return true
 		}
+	}
 
-		// Try to dig
-		if CanDigTo(world, newX, newY) {
-			DigAndMove(world, worker, newX, newY)
+	for _, dir := range closer {
+		dx, dy := DirectionToOffset(dir)
+		if (dx == 0 || dy == 0) && CanDigTo(world, pos.X+dx, pos.Y+dy) {
+			DigAndMove(world, worker, pos.X+dx, pos.Y+dy)
+			headed(worker, dir)
 			return true
 		}
 	}
 
-	return false
+	// Hemmed in: take whatever way round there is, even back the way it came
+	return StepToward(world, worker, target)
 }
 
-// MoveTowardTarget moves the worker toward a specific target
-func (wp *WorkerPathfinder) MoveTowardTarget(world *types.World, worker *types.WorkerAnt, target types.Position) bool {
-	// Calculate direction to target
-	dx := 0
-	dy := 0
-
-	if target.X > worker.Position.X {
-		dx = 1
-	} else if target.X < worker.Position.X {
-		dx = -1
-	}
-
-	if target.Y > worker.Position.Y {
-		dy = 1
-	} else if target.Y < worker.Position.Y {
-		dy = -1
-	}
-
-	// Try directions prioritized toward target
-	attempts := [][2]int{
-		{dx, dy},  // Diagonal toward
-		{dx, 0},   // Horizontal toward
-		{0, dy},   // Vertical toward
-		{dx, -dy}, // Alternate diagonal
-		{-dx, dy}, // Alternate diagonal
-		{0, -dy},  // Vertical away
-		{-dx, 0},  // Horizontal away
-	}
-
-	// First pass: try empty tunnels
-	for _, dir := range attempts {
-		if dir[0] == 0 && dir[1] == 0 {
-			continue
-		}
-		newX := worker.Position.X + dir[0]
-		newY := worker.Position.Y + dir[1]
-
-		if CanMoveTo(world, newX, newY) {
-			Move(world, worker, newX, newY)
-			return true
-		}
-	}
+// headed records the direction a worker last stepped in and spends its
+// momentum, so it picks a fresh direction when it next explores
+func headed(worker *types.WorkerAnt, dir Direction) {
+	worker.CurrentDirection = int(dir)
+	worker.MovesMade = worker.MovesInDirection
+}
 
-	// Second pass: dig if needed
-	for _, dir := range attempts {
-		if dir[0] == 0 && dir[1] == 0 {
+// HomeVectorDrift is how many cells a worker walks for each cell of error that
+// creeps into its home vector. Zero makes path integration perfect. Set it
+// with the -drift flag
+var HomeVectorDrift = 12
+
+// IntegratePath brings a worker's home vector up to date with however far it
+// has moved since it last did, a cell at a time. The worker runs this at the
+// start of its own turn, so moves made by anyone else cost no dice. Every
+// HomeVectorDrift cells walked the vector slips one cell in a random
+// direction, so the further a worker goes the less sure it is of the way back
+func (wp *WorkerPathfinder) IntegratePath(world *types.World, worker *types.WorkerAnt) {
+	dx := worker.Position.X - worker.Integrated.X
+	dy := worker.Position.Y - worker.Integrated.Y
+	worker.HomeVector.X -= dx
+	worker.HomeVector.Y -= dy
+	worker.Integrated = worker.Position
+
+	for step := max(util.Abs(dx), util.Abs(dy)); step > 0; step-- {
+		worker.Travelled++
+		if HomeVectorDrift <= 0 || worker.Travelled%HomeVectorDrift != 0 {
 			continue
 		}
-		newX := worker.Position.X + dir[0]
-		newY := worker.Position.Y + dir[1]
-
-		if CanDigTo(world, newX, newY) {
-			DigAndMove(world, worker, newX, newY)
-			return true
-		}
+		slip := GetCardinalDirections()[world.Random.Below(4)]
+		sx, sy := DirectionToOffset(slip)
+		worker.HomeVector.X += sx
+		worker.HomeVector.Y += sy
 	}
+}
 
-	return false
+// MoveTowardTarget moves the worker toward a specific target by the colony's
+// worker strategy
+func (wp *WorkerPathfinder) MoveTowardTarget(world *types.World, colony *types.Colony, worker *types.WorkerAnt, target types.Position) bool {
+	return MoverFor(colony, types.Worker).Toward(world, colony, worker, target)
 }
 
 // IsAdjacentToTarget checks if worker is next to target
@@ -74,11 +74,12 @@ func TestWorkerMoveTowardTarget(t *testing.T) {
 		world.GetCell(x, 10).IsTunnel = true
 	}
 
+	colony := types.NewColony("Red", 5, 5, types.ColonyRed)
 	worker := types.NewWorker(1, 5, 10, "Red")
 	world.GetCell(5, 10).Occupant = worker
 
 	target := types.Position{X: 15, Y: 10}
-	success := wp.MoveTowardTarget(world, worker, target)
+	success := wp.MoveTowardTarget(world, colony, worker, target)
 
 	if !success {
 		t.Error("Worker should be able to move toward target")
@@ -87,3 +88,237 @@ func TestWorkerMoveTowardTarget(t *testing.T) {
 		t.Error("Worker should have moved right toward target")
 	}
 }
+
+func TestWorkerFollowTrailAwayFromHome(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	wp := NewWorkerPathfinder()
+	home := types.Position{X: 10, Y: 10}
+
+	// A tunnel running left and right of the worker, with trail on both sides
+	for x := 10; x <= 14; x++ {
+		world.GetCell(x, 10).IsTunnel = true
+	}
+	worker := types.NewWorker(1, 12, 10, "Red")
+	world.GetCell(12, 10).Occupant = worker
+	world.Pheromones.Deposit("Red", types.Trail, 11, 10, 500) // Stronger, but back toward home
+	world.Pheromones.Deposit("Red", types.Trail, 13, 10, 50)
+
+	if !wp.FollowTrail(world, worker, home, 10) {
+		t.Fatal("Worker should follow the trail")
+	}
+	if worker.Position.X != 13 || worker.Position.Y != 10 {
+		t.Errorf("Worker should head away from home to (13,10), got (%d,%d)", worker.Position.X, worker.Position.Y)
+	}
+}
+
+func TestWorkerFollowTrailIgnoresFaintTrail(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	wp := NewWorkerPathfinder()
+	home := types.Position{X: 10, Y: 10}
+
+	for x := 10; x <= 14; x++ {
+		world.GetCell(x, 10).IsTunnel = true
+	}
+	worker := types.NewWorker(1, 12, 10, "Red")
+	world.GetCell(12, 10).Occupant = worker
+	world.Pheromones.Deposit("Red", types.Trail, 13, 10, 5)
+	world.Pheromones.Deposit("Blue", types.Trail, 13, 10, 500) // Another colony's trail
+
+	if wp.FollowTrail(world, worker, home, 10) {
+		t.Error("Worker should not follow a faint trail or another colony's trail")
+	}
+	if worker.Position.X != 12 {
+		t.Error("Worker should not have moved")
+	}
+}
+
+func TestWorkerFollowScentUphill(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	wp := NewWorkerPathfinder()
+
+	for x := 10; x <= 14; x++ {
+		world.GetCell(x, 10).IsTunnel = true
+	}
+	worker := types.NewWorker(1, 12, 10, "Red")
+	world.GetCell(12, 10).Occupant = worker
+	world.Scent[types.Carbohydrate][world.Index(11, 10)] = 3
+	world.Scent[types.Carbohydrate][world.Index(12, 10)] = 4
+	world.Scent[types.Carbohydrate][world.Index(13, 10)] = 5
+
+	if !wp.FollowScent(world, worker, types.Carbohydrate) {
+		t.Fatal("Worker should follow the scent")
+	}
+	if worker.Position.X != 13 {
+		t.Errorf("Worker should move toward the stronger scent at x=13, got x=%d", worker.Position.X)
+	}
+}
+
+func TestWorkerFollowScentNoGradient(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	wp := NewWorkerPathfinder()
+
+	worker := types.NewWorker(1, 12, 10, "Red")
+	world.GetCell(12, 10).IsTunnel = true
+	world.GetCell(12, 10).Occupant = worker
+
+	if wp.FollowScent(world, worker, types.Carbohydrate, types.Protein, types.Sugar) {
+		t.Error("Worker should not move with no scent around")
+	}
+}
+
+func TestWorkerFollowScentOnlyOfDiet(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	wp := NewWorkerPathfinder()
+
+	for x := 10; x <= 14; x++ {
+		world.GetCell(x, 10).IsTunnel = true
+	}
+	worker := types.NewWorker(1, 12, 10, "Red")
+	world.GetCell(12, 10).Occupant = worker
+	world.Scent[types.Carbohydrate][world.Index(13, 10)] = 8 // Seeds to the right
+	world.Scent[types.Protein][world.Index(11, 10)] = 3      // Fainter prey to the left
+
+	if !wp.FollowScent(world, worker, types.Protein) {
+		t.Fatal("Worker should follow the prey scent")
+	}
+	if worker.Position.X != 11 {
+		t.Errorf("Worker should ignore the seeds and head for the prey at x=11, got x=%d", worker.Position.X)
+	}
+}
+
+func TestIntegratePathKeepsWorkerHomeVector(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	wp := NewWorkerPathfinder()
+	worker := types.NewWorker(1, 10, 1, "Red")
+	world.GetCell(10, 1).Occupant = worker
+	worker.SetHome(types.Position{X: 10, Y: 1})
+
+	drift := HomeVectorDrift
+	HomeVectorDrift = 0
+	defer func() { HomeVectorDrift = drift }()
+
+	Move(world, worker, 11, 1)
+	wp.IntegratePath(world, worker)
+	Move(world, worker, 12, 0)
+	wp.IntegratePath(world, worker)
+
+	if worker.HomeVector != (types.Position{X: -2, Y: 1}) {
+		t.Errorf("Expected home vector (-2,1), got (%d,%d)", worker.HomeVector.X, worker.HomeVector.Y)
+	}
+	if worker.Travelled != 2 {
+		t.Errorf("Expected 2 cells travelled, got %d", worker.Travelled)
+	}
+}
+
+func TestHomeVectorDriftsWithDistance(t *testing.T) {
+	world := types.NewWorld(40, 20, random.New(1))
+	wp := NewWorkerPathfinder()
+	worker := types.NewWorker(1, 0, 1, "Red")
+	world.GetCell(0, 1).Occupant = worker
+	worker.SetHome(types.Position{X: 0, Y: 1})
+
+	for x := 1; x < HomeVectorDrift; x++ {
+		Move(world, worker, x, 1)
+		wp.IntegratePath(world, worker)
+	}
+	if home := worker.ReckonedHome(); home != (types.Position{X: 0, Y: 1}) {
+		t.Fatalf("No error expected before %d cells, reckons home at (%d,%d)", HomeVectorDrift, home.X, home.Y)
+	}
+
+	Move(world, worker, HomeVectorDrift, 1)
+	wp.IntegratePath(world, worker)
+	home := worker.ReckonedHome()
+	if ManhattanDistance(home, types.Position{X: 0, Y: 1}) != 1 {
+		t.Errorf("Expected home to slip one cell after %d cells, reckons (%d,%d)", HomeVectorDrift, home.X, home.Y)
+	}
+}
+
+func TestMoveLeavesHomeVectorAndDiceAlone(t *testing.T) {
+	world := types.NewWorld(40, 20, random.New(1))
+	worker := types.NewWorker(1, 0, 1, "Red")
+	world.GetCell(0, 1).Occupant = worker
+	worker.SetHome(types.Position{X: 0, Y: 1})
+	next := random.New(1)
+	world.Random = random.New(1)
+
+	for x := 1; x <= 2*HomeVectorDrift; x++ {
+		Move(world, worker, x, 1)
+	}
+
+	if worker.Travelled != 0 || worker.HomeVector != (types.Position{X: 0, Y: 0}) {
+		t.Error("Moving an ant should not touch its home vector")
+	}
+	if world.Random.Below(1000) != next.Below(1000) {
+		t.Error("Moving an ant should not draw from the world's random source")
+	}
+}
+
+func TestBringFoodToQueenFollowsHomeVectorOnSurface(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	wp := NewWorkerPathfinder()
+	colony := types.NewColony("Red", 10, 10, types.ColonyRed)
+
+	// Out on the surface the worker reckons home is off to the left, though
+	// the queen is below and to the right
+	worker := types.NewWorker(2, 5, 1, "Red")
+	worker.CarryingFood = true
+	world.GetCell(5, 1).Occupant = worker
+	worker.HomeVector = types.Position{X: -3, Y: 0}
+
+	if !wp.BringFoodToQueen(world, colony, worker) {
+		t.Fatal("Worker should be able to head home")
+	}
+	if worker.Position != (types.Position{X: 4, Y: 1}) {
+		t.Errorf("Worker should follow its vector left, got (%d,%d)", worker.Position.X, worker.Position.Y)
+	}
+	wp.IntegratePath(world, worker)
+	if worker.HomeVector != (types.Position{X: -2, Y: 0}) {
+		t.Errorf("Vector should shrink as the worker goes, got (%d,%d)", worker.HomeVector.X, worker.HomeVector.Y)
+	}
+}
+
+func TestBringFoodToQueenIgnoresHomeVectorUnderground(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	wp := NewWorkerPathfinder()
+	colony := types.NewColony("Red", 10, 10, types.ColonyRed)
+
+	// Down in the tunnels the worker can smell the nest, so a vector pointing
+	// straight up is no longer what it goes by
+	worker := types.NewWorker(2, 10, 5, "Red")
+	worker.CarryingFood = true
+	for y := 4; y <= 10; y++ {
+		world.GetCell(10, y).IsTunnel = true
+	}
+	world.GetCell(10, 5).Occupant = worker
+	worker.HomeVector = types.Position{X: 0, Y: -3}
+
+	if !wp.BringFoodToQueen(world, colony, worker) {
+		t.Fatal("Worker should be able to head home")
+	}
+	if worker.Position != (types.Position{X: 10, Y: 6}) {
+		t.Errorf("Worker should head down to the stores, got (%d,%d)", worker.Position.X, worker.Position.Y)
+	}
+}
+
+func TestBringFoodToQueenDetoursThroughTunnel(t *testing.T) {
+	world := types.NewWorld(20, 20, random.New(1))
+	wp := NewWorkerPathfinder()
+	colony := types.NewColony("Red", 12, 15, types.ColonyRed)
+
+	// Down is solid, but there is open tunnel to the side that also closes the distance
+	worker := types.NewWorker(2, 10, 5, "Red")
+	worker.CarryingFood = true
+	world.GetCell(10, 5).IsTunnel = true
+	world.GetCell(11, 5).IsTunnel = true
+	world.GetCell(10, 5).Occupant = worker
+	worker.SetHome(colony.QueenPosition)
+
+	wp.BringFoodToQueen(world, colony, worker)
+
+	if worker.Position != (types.Position{X: 11, Y: 5}) {
+		t.Errorf("Worker should take the open tunnel rather than dig, got (%d,%d)", worker.Position.X, worker.Position.Y)
+	}
+	if world.GetCell(10, 6).IsTunnel {
+		t.Error("Worker should not have dug")
+	}
+}
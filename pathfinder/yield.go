@@ -0,0 +1,140 @@
+package pathfinder
+
+import (
+	"antfarm/types"
+)
+
+// yield.go - Getting past nestmates in narrow tunnels
+// Two nestmates meeting head-on swap places rather than both stopping. An ant
+// carrying a load has right of way: it swaps past any unladen nestmate, and an
+// unladen ant that runs into a laden one steps back out of its way. Queens and
+// larvae are never pushed about, and ants of other colonies are never passed.
+
+// Pass tries to get an ant into a cell a nestmate is standing in
+// The two swap places if the nestmate is coming the other way or the ant has
+// right of way over it. If the nestmate has right of way instead, the ant
+// backs off. Returns true if the ant moved either way
+func Pass(world *types.World, ant types.AntInterface, x, y int) bool {
+	other := blocker(world, ant, x, y)
+	if other == nil {
+		return false
+	}
+	if CanPass(world, ant, x, y) {
+		Swap(world, ant, other)
+		return true
+	}
+	if MustYield(world, ant, x, y) {
+		return BackOff(world, ant, other.GetAnt().Position)
+	}
+	// Mid-tick a nestmate going the same way may be about to move on, so fall
+	// in behind it and let CommitMoves decide if there is room
+	if world.Traffic != nil && world.Traffic.Pending && following(ant, other, x, y) {
+		Move(world, ant, x, y)
+		return true
+	}
+	return false
+}
+
+// CanGetPast reports whether Pass would do anything about the ant in a cell
+func CanGetPast(world *types.World, ant types.AntInterface, x, y int) bool {
+	other := blocker(world, ant, x, y)
+	if other == nil {
+		return false
+	}
+	if CanPass(world, ant, x, y) || MustYield(world, ant, x, y) {
+		return true
+	}
+	return world.Traffic != nil && world.Traffic.Pending && following(ant, other, x, y)
+}
+
+// CanPass reports whether an ant may swap places with the nestmate in a cell
+func CanPass(world *types.World, ant types.AntInterface, x, y int) bool {
+	other := blocker(world, ant, x, y)
+	if other == nil {
+		return false
+	}
+	if loaded(ant) != loaded(other) {
+		return loaded(ant)
+	}
+	// Equals only swap when they meet head-on
+	pos := ant.GetAnt().Position
+	heading := other.GetAnt().Heading
+	return heading.X*(pos.X-x)+heading.Y*(pos.Y-y) > 0
+}
+
+// Swap exchanges two ants' places
+func Swap(world *types.World, a, b types.AntInterface) {
+	posA := a.GetAnt().Position
+	posB := b.GetAnt().Position
+	Move(world, a, posB.X, posB.Y)
+	Move(world, b, posA.X, posA.Y)
+}
+
+// BackOff steps an ant into the free open cell furthest from whatever it is
+// making way for. Ties go to the first in direction order, so the same meeting
+// always plays out the same way. Returns false if there is nowhere to go
+func BackOff(world *types.World, ant types.AntInterface, from types.Position) bool {
+	pos := ant.GetAnt().Position
+	bestDir := DirIdle
+	bestDist := ManhattanDistance(pos, from)
+	for _, dir := range GetAllDirections() {
+		dx, dy := DirectionToOffset(dir)
+		next := types.Position{X: pos.X + dx, Y: pos.Y + dy}
+		if dist := ManhattanDistance(next, from); dist > bestDist && CanMoveTo(world, next.X, next.Y) {
+			bestDir = dir
+			bestDist = dist
+		}
+	}
+	if bestDir == DirIdle {
+		return false
+	}
+
+	dx, dy := DirectionToOffset(bestDir)
+	Move(world, ant, pos.X+dx, pos.Y+dy)
+	ant.GetAnt().CurrentAction = "making way"
+	return true
+}
+
+// blocker returns the nestmate in a cell next to an ant if it is one that can
+// be passed, or nil
+func blocker(world *types.World, ant types.AntInterface, x, y int) types.AntInterface {
+	cell := world.GetCell(x, y)
+	if cell == nil || !cell.IsTunnel || cell.Occupant == nil || cell.Critter != nil || cell.Corpse != nil {
+		return nil
+	}
+	other := cell.Occupant
+	if other == ant || other.GetAnt().ColonyID != ant.GetAnt().ColonyID ||
+		!IsAdjacent(ant.GetAnt().Position, other.GetAnt().Position) {
+		return nil
+	}
+	switch other.GetRole() {
+	case types.Queen, types.Larvae:
+		return nil
+	}
+	return other
+}
+
+// following reports whether the nestmate in a cell was last heading the way
+// an ant wants to step into it
+func following(ant, other types.AntInterface, x, y int) bool {
+	pos := ant.GetAnt().Position
+	heading := other.GetAnt().Heading
+	return heading.X*(x-pos.X)+heading.Y*(y-pos.Y) > 0
+}
+
+// MustYield reports whether an ant has to make way for the nestmate in a cell
+func MustYield(world *types.World, ant types.AntInterface, x, y int) bool {
+	other := blocker(world, ant, x, y)
+	return other != nil && loaded(other) && !loaded(ant)
+}
+
+// loaded reports whether an ant is carrying something
+func loaded(ant types.AntInterface) bool {
+	switch a := ant.(type) {
+	case *types.WorkerAnt:
+		return a.CarryingFood || a.CarryingCorpse
+	case *types.NurseAnt:
+		return a.FoodAmount > 0
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package pathfinder
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+// corridor returns a world with a one-cell-wide tunnel along y=10 from x=2 to x=15
+func corridor() *types.World {
+	world := types.NewWorld(20, 20, random.New(1))
+	for x := 2; x <= 15; x++ {
+		world.GetCell(x, 10).IsTunnel = true
+	}
+	return world
+}
+
+func place(world *types.World, ant types.AntInterface) {
+	pos := ant.GetAnt().Position
+	world.GetCell(pos.X, pos.Y).Occupant = ant
+}
+
+func TestMoveLeavesSomeoneElsesCellAlone(t *testing.T) {
+	world := corridor()
+	a := types.NewWorker(1, 5, 10, "Red")
+	b := types.NewWorker(2, 6, 10, "Red")
+	place(world, a)
+	// b has already been put in a's cell by something else
+	world.GetCell(5, 10).Occupant = b
+
+	Move(world, a, 4, 10)
+
+	if world.GetCell(5, 10).Occupant != b {
+		t.Error("Moving a should not clear a cell b now stands in")
+	}
+	if world.GetCell(4, 10).Occupant != a || a.Heading != (types.Position{X: -1, Y: 0}) {
+		t.Errorf("a should be at (4,10) heading left, heading %v", a.Heading)
+	}
+}
+
+func TestHeadOnNestmatesSwap(t *testing.T) {
+	world := corridor()
+	a := types.NewWorker(1, 5, 10, "Red")
+	b := types.NewWorker(2, 6, 10, "Red")
+	place(world, a)
+	place(world, b)
+	a.Heading = types.Position{X: 1, Y: 0}
+	b.Heading = types.Position{X: -1, Y: 0}
+
+	if !Pass(world, a, 6, 10) {
+		t.Fatal("Nestmates meeting head-on should swap")
+	}
+	if a.Position.X != 6 || b.Position.X != 5 {
+		t.Errorf("Expected a at 6 and b at 5, got %v and %v", a.Position, b.Position)
+	}
+	if world.GetCell(5, 10).Occupant != b || world.GetCell(6, 10).Occupant != a {
+		t.Error("Cells should hold the swapped ants")
+	}
+}
+
+func TestSameWayNestmatesDoNotSwap(t *testing.T) {
+	world := corridor()
+	a := types.NewWorker(1, 5, 10, "Red")
+	b := types.NewWorker(2, 6, 10, "Red")
+	place(world, a)
+	place(world, b)
+	b.Heading = types.Position{X: 1, Y: 0}
+
+	if Pass(world, a, 6, 10) {
+		t.Error("An ant should not push past a nestmate going its way")
+	}
+}
+
+func TestLoadedAntHasRightOfWay(t *testing.T) {
+	world := corridor()
+	loadedAnt := types.NewWorker(1, 5, 10, "Red")
+	loadedAnt.CarryingFood = true
+	idle := types.NewWorker(2, 6, 10, "Red")
+	place(world, loadedAnt)
+	place(world, idle)
+
+	if !Pass(world, loadedAnt, 6, 10) {
+		t.Fatal("A loaded ant should swap past an unladen one")
+	}
+	if loadedAnt.Position.X != 6 {
+		t.Errorf("Loaded ant should be at 6, got %v", loadedAnt.Position)
+	}
+}
+
+func TestUnladenAntBacksOff(t *testing.T) {
+	world := corridor()
+	loadedAnt := types.NewWorker(1, 6, 10, "Red")
+	loadedAnt.CarryingFood = true
+	loadedAnt.Heading = types.Position{X: -1, Y: 0}
+	idle := types.NewWorker(2, 5, 10, "Red")
+	place(world, loadedAnt)
+	place(world, idle)
+
+	if !Pass(world, idle, 6, 10) {
+		t.Fatal("An unladen ant should back off from a loaded one")
+	}
+	if idle.Position != (types.Position{X: 4, Y: 10}) || loadedAnt.Position.X != 6 {
+		t.Errorf("Unladen ant should step back to (4,10), got %v", idle.Position)
+	}
+}
+
+func TestNoPassingStrangersOrTheQueen(t *testing.T) {
+	world := corridor()
+	a := types.NewWorker(1, 5, 10, "Red")
+	a.CarryingFood = true
+	stranger := types.NewWorker(2, 6, 10, "Blue")
+	queen := types.NewQueen(3, 4, 10, "Red")
+	place(world, a)
+	place(world, stranger)
+	place(world, queen)
+
+	if Pass(world, a, 6, 10) {
+		t.Error("Ants of another colony should never be passed")
+	}
+	if Pass(world, a, 4, 10) {
+		t.Error("The queen should never be pushed about")
+	}
+}
+
+func TestStepDownhillPassesNestmate(t *testing.T) {
+	world := corridor()
+	field := types.NewDistanceField(world, types.Position{X: 2, Y: 10})
+	worker := types.NewWorker(1, 8, 10, "Red")
+	worker.CarryingFood = true
+	idle := types.NewWorker(2, 7, 10, "Red")
+	place(world, worker)
+	place(world, idle)
+
+	if !StepDownhill(world, worker, field) {
+		t.Fatal("Loaded worker should get past the nestmate on its way home")
+	}
+	if worker.Position.X != 7 || idle.Position.X != 8 {
+		t.Errorf("Expected the two to swap, got %v and %v", worker.Position, idle.Position)
+	}
+}
@@ -0,0 +1,169 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+)
+
+// alarm.go - Alarm pheromone and the colony's response to danger
+// An ant that spots a spider or an ant from another colony close by marks the
+// spot with alarm pheromone. Alarm spreads fast and fades fast (see
+// pheromones.go), so it only lasts while the threat is still about. Soldiers
+// converge on it, and soldiers near the queen close in around her if it
+// reaches her. Workers back away from it, and nurses carry their brood deeper.
+
+// Alarm tuning
+var (
+	alarmSenseRange   = 2   // How close a threat has to be before an ant notices it
+	alarmDeposit      = 400 // Alarm an ant lays each tick it can see a threat
+	alarmFleeLevel    = 50  // Alarm at which workers back away and nurses move brood
+	alarmRespondRange = 10  // How far away a soldier can sense alarm
+	alarmRespondLevel = 10  // Weakest alarm a soldier responds to
+	queenGuardRange   = 6   // Soldiers this close to the queen guard her when alarm reaches her
+)
+
+// isThreat reports whether a cell holds something dangerous to the given ant
+func isThreat(cell *types.Cell, ant *types.Ant) bool {
+	if cell.Critter != nil && cell.Critter.Kind == types.Spider {
+		return true
+	}
+	return cell.Occupant != nil && cell.Occupant.GetAnt().ColonyID != ant.ColonyID
+}
+
+// senseThreat looks around an ant for a spider or an enemy ant and lays alarm
+// where it stands if it sees one. The colony remembers where the threat was
+// so patrols can look in on it. Returns true if it raised the alarm
+func senseThreat(world *types.World, colony *types.Colony, ant *types.Ant) bool {
+	for dy := -alarmSenseRange; dy <= alarmSenseRange; dy++ {
+		for dx := -alarmSenseRange; dx <= alarmSenseRange; dx++ {
+			cell := world.GetCell(ant.Position.X+dx, ant.Position.Y+dy)
+			if cell != nil && isThreat(cell, ant) {
+				raiseAlarm(world, ant)
+				colony.ReportThreat(types.Position{X: ant.Position.X + dx, Y: ant.Position.Y + dy}, world.Ticks)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// raiseAlarm lays alarm pheromone where the ant is standing
+// Any ant that is attacked should call this as well as ants that spot a threat
+func raiseAlarm(world *types.World, ant *types.Ant) {
+	layPheromone(world, ant, types.Alarm, alarmDeposit)
+}
+
+// alarmAround returns the strongest alarm in or next to a cell
+func alarmAround(world *types.World, colonyID string, pos types.Position) int {
+	level := world.Pheromones.Level(colonyID, types.Alarm, pos.X, pos.Y)
+	if _, near := world.Pheromones.Strongest(colonyID, types.Alarm, pos.X, pos.Y); near > level {
+		level = near
+	}
+	return level
+}
+
+// fleeAlarm backs an ant away from strong alarm into the open neighbour with
+// the least of it. Returns false if there is no alarm worth running from
+func fleeAlarm(world *types.World, ant types.AntInterface) bool {
+	baseAnt := ant.GetAnt()
+	pos := baseAnt.Position
+	if alarmAround(world, baseAnt.ColonyID, pos) < alarmFleeLevel {
+		return false
+	}
+
+	baseAnt.CurrentAction = "fleeing alarm"
+	bestLevel := world.Pheromones.Level(baseAnt.ColonyID, types.Alarm, pos.X, pos.Y)
+	bestX, bestY := -1, -1
+	for _, dir := range pathfinder.GetAllDirections() {
+		dx, dy := pathfinder.DirectionToOffset(dir)
+		newX, newY := pos.X+dx, pos.Y+dy
+		if !pathfinder.CanMoveTo(world, newX, newY) {
+			continue
+		}
+		if level := world.Pheromones.Level(baseAnt.ColonyID, types.Alarm, newX, newY); level < bestLevel {
+			bestLevel = level
+			bestX, bestY = newX, newY
+		}
+	}
+	if bestX != -1 {
+		pathfinder.Move(world, ant, bestX, bestY)
+	}
+	return true
+}
+
+// protectBrood has a nurse carry her larvae one cell deeper when alarm reaches it
+// She hurries over first if she is not beside it. Returns false if her larvae
+// is not in danger
+func protectBrood(world *types.World, colony *types.Colony, nurse *types.NurseAnt) bool {
+	larvae := nurse.CurrentlyNursing
+	if larvae == nil || !hasLarvae(colony, larvae) ||
+		alarmAround(world, colony.Name, larvae.Position) < alarmFleeLevel {
+		return false
+	}
+
+	if !pathfinder.IsAdjacent(nurse.Position, larvae.Position) {
+		nurse.CurrentAction = "hurrying to brood"
+		pathfinder.StepToward(world, nurse, larvae.Position)
+		return true
+	}
+
+	nurse.CurrentAction = "moving brood deeper"
+	belowX, belowY := larvae.Position.X, larvae.Position.Y+1
+	if pathfinder.CanDigTo(world, belowX, belowY) {
+		// The nurse digs the new brood cell herself
+		world.Dig(belowX, belowY)
+		nurse.Health--
+	}
+	if pathfinder.CanMoveTo(world, belowX, belowY) {
+		pathfinder.Move(world, larvae, belowX, belowY)
+	}
+	return true
+}
+
+// guardQueen pulls soldiers near the queen in around her while alarm is at her
+// chamber. Returns false if the queen is safe or the soldier is too far off
+func guardQueen(world *types.World, colony *types.Colony, soldier *types.SoldierAnt) bool {
+	queen := colony.Queen
+	if queen == nil || pathfinder.ManhattanDistance(soldier.Position, queen.Position) > queenGuardRange ||
+		alarmAround(world, colony.Name, queen.Position) < alarmRespondLevel {
+		return false
+	}
+
+	if pathfinder.IsAdjacent(soldier.Position, queen.Position) {
+		soldier.CurrentAction = "guarding queen"
+		return true
+	}
+
+	soldier.CurrentAction = "closing in on queen"
+	pathfinder.StepToward(world, soldier, queen.Position)
+	return true
+}
+
+// respondToAlarm sends a soldier toward the strongest alarm within its range
+// Returns false if it cannot sense any alarm
+func respondToAlarm(world *types.World, colony *types.Colony, soldier *types.SoldierAnt) bool {
+	pos := soldier.Position
+	var source types.Position
+	bestLevel := alarmRespondLevel - 1
+	for dy := -alarmRespondRange; dy <= alarmRespondRange; dy++ {
+		for dx := -alarmRespondRange; dx <= alarmRespondRange; dx++ {
+			level := world.Pheromones.Level(colony.Name, types.Alarm, pos.X+dx, pos.Y+dy)
+			if level > bestLevel {
+				bestLevel = level
+				source = types.Position{X: pos.X + dx, Y: pos.Y + dy}
+			}
+		}
+	}
+	if bestLevel < alarmRespondLevel {
+		return false
+	}
+
+	if pathfinder.IsAdjacentOrSame(pos, source) {
+		soldier.CurrentAction = "defending"
+		return true
+	}
+
+	soldier.CurrentAction = "responding to alarm"
+	pathfinder.StepToward(world, soldier, source)
+	return true
+}
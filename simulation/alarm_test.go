@@ -0,0 +1,138 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestAntRaisesAlarmAtSpider(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	worker := types.NewWorker(1, 10, 1, "Red")
+	world.GetCell(10, 1).Occupant = worker
+	AddCritter(world, types.NewSpider(world.NextCritterID, 12, 1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+
+	if !senseThreat(world, colony, worker.Ant) {
+		t.Fatal("Worker should notice the spider")
+	}
+	if got := world.Pheromones.Level("Red", types.Alarm, 10, 1); got != alarmDeposit {
+		t.Errorf("Expected alarm %d where the worker stands, got %d", alarmDeposit, got)
+	}
+	if len(colony.Sightings) != 1 || colony.Sightings[0].Position != (types.Position{X: 12, Y: 1}) {
+		t.Errorf("Colony should remember the spider at (12,1), got %v", colony.Sightings)
+	}
+}
+
+func TestAntRaisesAlarmAtEnemy(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	worker := types.NewWorker(1, 10, 1, "Red")
+	world.GetCell(10, 1).Occupant = worker
+	enemy := types.NewWorker(2, 11, 1, "Blue")
+	world.GetCell(11, 1).Occupant = enemy
+
+	if !senseThreat(world, types.NewColony("Red", 20, 15, types.ColonyRed), worker.Ant) {
+		t.Error("Worker should notice the enemy ant")
+	}
+}
+
+func TestNestmatesAreNoThreat(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	worker := types.NewWorker(1, 10, 1, "Red")
+	world.GetCell(10, 1).Occupant = worker
+	friend := types.NewWorker(2, 11, 1, "Red")
+	world.GetCell(11, 1).Occupant = friend
+	AddCritter(world, types.NewAphid(world.NextCritterID, 9, 1))
+
+	if senseThreat(world, types.NewColony("Red", 20, 15, types.ColonyRed), worker.Ant) {
+		t.Error("Nestmates and aphids should not raise the alarm")
+	}
+}
+
+func TestWorkerFleesAlarm(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	worker := types.NewWorker(1, 10, 1, "Red")
+	world.GetCell(10, 1).Occupant = worker
+	world.Pheromones.Deposit("Red", types.Alarm, 11, 1, 300)
+	world.Pheromones.Deposit("Red", types.Alarm, 10, 1, 200)
+
+	if !fleeAlarm(world, worker) {
+		t.Fatal("Worker should flee strong alarm")
+	}
+	if worker.Position.X > 10 || worker.Position == (types.Position{X: 10, Y: 1}) {
+		t.Errorf("Worker should back away from the alarm, got (%d,%d)", worker.Position.X, worker.Position.Y)
+	}
+}
+
+func TestSoldierConvergesOnAlarm(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	for x := 5; x <= 15; x++ {
+		world.GetCell(x, 1).IsTunnel = true
+	}
+	soldier := SpawnSoldier(colony, 5, 1)
+	PlaceAnt(world, soldier)
+	world.Pheromones.Deposit("Red", types.Alarm, 12, 1, 300)
+
+	if !respondToAlarm(world, colony, soldier) {
+		t.Fatal("Soldier should respond to alarm within range")
+	}
+	if soldier.Position.X != 6 {
+		t.Errorf("Soldier should step toward the alarm, got x=%d", soldier.Position.X)
+	}
+}
+
+func TestSoldierIgnoresDistantAlarm(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	soldier := SpawnSoldier(colony, 5, 1)
+	PlaceAnt(world, soldier)
+	world.Pheromones.Deposit("Red", types.Alarm, 5+alarmRespondRange+1, 1, 300)
+
+	if respondToAlarm(world, colony, soldier) {
+		t.Error("Soldier should not sense alarm out of range")
+	}
+}
+
+func TestSoldiersGuardQueenUnderAlarm(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	soldier := SpawnSoldier(colony, 20, 18)
+	PlaceAnt(world, soldier)
+
+	if guardQueen(world, colony, soldier) {
+		t.Error("Soldier should not guard the queen with no alarm")
+	}
+
+	world.Pheromones.Deposit("Red", types.Alarm, 20, 15, 300)
+	if !guardQueen(world, colony, soldier) {
+		t.Fatal("Soldier near the queen should close in when alarm reaches her")
+	}
+	if soldier.CurrentAction != "closing in on queen" {
+		t.Errorf("Expected soldier to close in, got '%s'", soldier.CurrentAction)
+	}
+}
+
+func TestNurseMovesBroodDeeper(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	larvae := SpawnLarvae(colony, 22, 15)
+	PlaceAnt(world, larvae)
+	nurse := colony.HeadNurse // At (21,15)
+	nurse.CurrentlyNursing = larvae
+	world.Pheromones.Deposit("Red", types.Alarm, 22, 15, 300)
+
+	if !protectBrood(world, colony, nurse) {
+		t.Fatal("Nurse should protect her larvae from the alarm")
+	}
+	if larvae.Position.Y != 16 {
+		t.Errorf("Larvae should be carried one cell deeper, got y=%d", larvae.Position.Y)
+	}
+	if world.GetCell(22, 16).Occupant != larvae || world.GetCell(22, 15).Occupant != nil {
+		t.Error("Larvae should have moved cells in the grid")
+	}
+}
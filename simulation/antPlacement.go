@@ -9,6 +9,7 @@ import (
 
 // AddColony places a new colony in the world
 // Digs an initial chamber and places ALL ants (queen, nurses, etc.) in the world
+// The chamber is dug with world.Dig, so colonies already there see it open up
 func AddColony(world *types.World, colony *types.Colony) {
 	world.Colonies = append(world.Colonies, colony)
 
@@ -16,9 +17,8 @@ func AddColony(world *types.World, colony *types.Colony) {
 	if colony.Queen != nil {
 		pos := colony.Queen.Position
 		if world.IsValidPosition(pos.X, pos.Y) {
-			cell := world.GetCell(pos.X, pos.Y)
-			cell.IsTunnel = true
-			cell.Occupant = colony.Queen
+			world.Dig(pos.X, pos.Y)
+			world.GetCell(pos.X, pos.Y).Occupant = colony.Queen
 		}
 	}
 
@@ -26,9 +26,8 @@ func AddColony(world *types.World, colony *types.Colony) {
 	if colony.HeadNurse != nil {
 		pos := colony.HeadNurse.Position
 		if world.IsValidPosition(pos.X, pos.Y) {
-			cell := world.GetCell(pos.X, pos.Y)
-			cell.IsTunnel = true
-			cell.Occupant = colony.HeadNurse
+			world.Dig(pos.X, pos.Y)
+			world.GetCell(pos.X, pos.Y).Occupant = colony.HeadNurse
 		}
 	}
 
@@ -36,8 +35,8 @@ func AddColony(world *types.World, colony *types.Colony) {
 	for _, nurse := range colony.Nurses {
 		pos := nurse.Position
 		if world.IsValidPosition(pos.X, pos.Y) {
+			world.Dig(pos.X, pos.Y) // founders arrive in fresh soil
 			cell := world.GetCell(pos.X, pos.Y)
-			cell.IsTunnel = true // founders arrive in fresh soil
 			if cell.Occupant == nil {
 				cell.Occupant = nurse
 			}
@@ -48,8 +47,8 @@ func AddColony(world *types.World, colony *types.Colony) {
 	for _, worker := range colony.Workers {
 		pos := worker.Position
 		if world.IsValidPosition(pos.X, pos.Y) {
+			world.Dig(pos.X, pos.Y) // founders arrive in fresh soil
 			cell := world.GetCell(pos.X, pos.Y)
-			cell.IsTunnel = true // founders arrive in fresh soil
 			if cell.Occupant == nil {
 				cell.Occupant = worker
 			}
@@ -60,13 +59,16 @@ func AddColony(world *types.World, colony *types.Colony) {
 	for _, soldier := range colony.Soldiers {
 		pos := soldier.Position
 		if world.IsValidPosition(pos.X, pos.Y) {
+			world.Dig(pos.X, pos.Y) // founders arrive in fresh soil
 			cell := world.GetCell(pos.X, pos.Y)
-			cell.IsTunnel = true // founders arrive in fresh soil
 			if cell.Occupant == nil {
 				cell.Occupant = soldier
 			}
 		}
 	}
+
+	// Measure the way home through the chamber just dug
+	colony.HomeField = types.NewDistanceField(world, colony.QueenPosition)
 }
 
 // PlaceAnt places any ant type into the world at its current position
@@ -77,7 +79,7 @@ func PlaceAnt(world *types.World, ant types.AntInterface) bool {
 	}
 
 	cell := world.GetCell(pos.X, pos.Y)
-	if cell.IsTunnel && cell.Occupant == nil {
+	if cell.IsTunnel && cell.Occupant == nil && cell.Critter == nil && cell.Corpse == nil {
 		cell.Occupant = ant
 		return true
 	}
@@ -102,7 +104,7 @@ func MoveAnt(world *types.World, ant types.AntInterface, newX, newY int) bool {
 	}
 
 	newCell := world.GetCell(newX, newY)
-	if newCell == nil || !newCell.IsTunnel || newCell.Occupant != nil {
+	if newCell == nil || !newCell.IsTunnel || newCell.Occupant != nil || newCell.Critter != nil {
 		return false
 	}
 
@@ -154,3 +154,41 @@ func TestMoveAntFailsOnOccupied(t *testing.T) {
 		t.Error("MoveAnt should fail on occupied cell")
 	}
 }
+
+func TestAddColonyMeasuresHomeField(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+
+	AddColony(world, colony)
+
+	if colony.HomeField == nil {
+		t.Fatal("Colony should have a home field once placed")
+	}
+	if got := colony.HomeField.At(21, 15); got != 1 {
+		t.Errorf("The head nurse's cell should be 1 step from the queen, got %d", got)
+	}
+
+	// Digging on from the chamber extends the field
+	world.Dig(22, 15)
+	if got := colony.HomeField.At(22, 15); got != 2 {
+		t.Errorf("A freshly dug cell should be 2 steps from the queen, got %d", got)
+	}
+}
+
+func TestAddColonyDigsItsChamberThroughTheWorld(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	first := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, first)
+	edits := world.TunnelEdits
+
+	// Founded right beside the first, so the chambers run into each other
+	second := types.NewColony("Blue", 23, 15, types.ColonyBlue)
+	AddColony(world, second)
+
+	if world.TunnelEdits <= edits {
+		t.Error("Digging the founding chamber should count as tunnel edits")
+	}
+	if first.HomeField.At(23, 15) == types.Unreachable {
+		t.Error("The first colony's home field should reach into the new chamber")
+	}
+}
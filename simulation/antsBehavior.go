@@ -11,6 +11,7 @@ import (
 // Each ant role has different behavior patterns
 
 // Pathfinders - reusable instances
+// How they move is up to each colony's choice of strategy (see pathfinder.Mover)
 var (
 	workerPathfinder = pathfinder.NewWorkerPathfinder()
 	nursePathfinder  = pathfinder.NewNursePathfinder()
@@ -19,128 +20,255 @@ var (
 // updateWorker performs one tick of behavior for a worker ant
 func updateWorker(world *types.World, colony *types.Colony, worker *types.WorkerAnt) {
 	worker.Age++
+	metabolize(world, worker.Ant)
+	worker.FadeFoodMemory()
+	// Catch the home vector up with the worker's last move, unless it is
+	// beside the queen and knows exactly where home is
+	workerPathfinder.IntegratePath(world, worker)
+	if pathfinder.IsAdjacentOrSame(worker.Position, colony.QueenPosition) {
+		worker.SetHome(colony.QueenPosition)
+	}
+	if isSluggish(world, worker.Ant) {
+		worker.CurrentAction = "sick"
+		return
+	}
+	if isolate(world, colony, worker) {
+		return
+	}
+	markTerritory(world, worker.Ant)
+	senseThreat(world, colony, worker.Ant)
+	if fight(world, colony, worker) || fleeAlarm(world, worker) {
+		return
+	}
+	// Unladen workers keep off ground another colony holds
+	if !worker.CarryingFood && !worker.CarryingCorpse && avoidForeignTerritory(world, colony, worker) {
+		return
+	}
 	workerBehavior(world, colony, worker)
 }
 
 // updateSoldier performs one tick of behavior for a soldier ant
-func updateSoldier(_ *types.World, soldier *types.SoldierAnt) {
+func updateSoldier(world *types.World, colony *types.Colony, soldier *types.SoldierAnt) {
 	soldier.Age++
-	// TODO: Implement soldier patrol/combat behavior
-	soldier.CurrentAction = "patrolling"
+	metabolize(world, soldier.Ant)
+	if isSluggish(world, soldier.Ant) {
+		soldier.CurrentAction = "sick"
+		return
+	}
+	if isolate(world, colony, soldier) {
+		return
+	}
+	markTerritory(world, soldier.Ant)
+	senseThreat(world, colony, soldier.Ant)
+	if fight(world, colony, soldier) {
+		return
+	}
+	if guardQueen(world, colony, soldier) || respondToAlarm(world, colony, soldier) {
+		return
+	}
+	if contestTerritory(world, soldier) {
+		return
+	}
+	if seekMeal(world, colony, soldier) || guardHerd(world, colony, soldier) {
+		return
+	}
+	if !patrol(world, colony, soldier) {
+		soldier.CurrentAction = "standing guard"
+	}
 }
 
 // updateNurse performs one tick of behavior for the nurse ant
 func updateNurse(world *types.World, colony *types.Colony, nurse *types.NurseAnt) {
 	nurse.Age++
+	metabolize(world, nurse.Ant)
+	if isSluggish(world, nurse.Ant) {
+		nurse.CurrentAction = "sick"
+		return
+	}
+	markTerritory(world, nurse.Ant)
+	senseThreat(world, colony, nurse.Ant)
+	if fight(world, colony, nurse) || protectBrood(world, colony, nurse) || fleeAlarm(world, nurse) {
+		return
+	}
+	if seekMeal(world, colony, nurse) {
+		return
+	}
+	if groomNestmate(world, colony, nurse) {
+		return
+	}
 	nurseBehavior(world, colony, nurse)
 }
 
 // workerBehavior defines how worker ants act
-// Workers dig tunnels, gather food, and bring it back to the queen
+// Workers dig tunnels, gather food, and bring it back to the queen. Cut grass
+// goes to the fungus garden, and idle workers tend the garden when it needs it.
 func workerBehavior(world *types.World, colony *types.Colony, worker *types.WorkerAnt) {
-	// If carrying food, bring it back to queen
+	// Cut grass goes down to the fungus garden rather than the stores
+	if worker.CarryingFood && worker.CarryingGrass {
+		if garden := gardenForGrass(world, colony); garden != nil {
+			if pathfinder.IsAdjacentOrSame(worker.Position, garden.Position) {
+				// Dig out the chamber if this is the first load
+				world.Dig(garden.Position.X, garden.Position.Y)
+				garden.Vegetation += worker.FoodAmount
+				colony.Deposits++
+				worker.Deposits++
+				worker.CarryingFood = false
+				worker.CarryingGrass = false
+				worker.FoodAmount = 0
+				worker.CurrentAction = "added grass to fungus garden"
+				return
+			}
+
+			worker.CurrentAction = "bringing grass to fungus garden"
+			if !workerPathfinder.MoveTowardTarget(world, colony, worker, garden.Position) {
+				worker.CurrentAction = "stuck with grass"
+			}
+			return
+		}
+	}
+
+	// If carrying food, bring it back to the stores
 	if worker.CarryingFood {
-		// Check if adjacent to queen
-		xDist := util.Abs(colony.QueenPosition.X - worker.Position.X)
-		yDist := util.Abs(colony.QueenPosition.Y - worker.Position.Y)
+		// Check if adjacent to the stores
+		store := colony.StorePosition()
+		xDist := util.Abs(store.X - worker.Position.X)
+		yDist := util.Abs(store.Y - worker.Position.Y)
 
 		if xDist <= 1 && yDist <= 1 {
 			// Deposit food
-			colony.Food += worker.FoodAmount
+			colony.Food[worker.FoodType] += worker.FoodAmount
+			colony.Deposits++
+			worker.Deposits++
 			worker.CarryingFood = false
+			worker.CarryingGrass = false
 			worker.FoodAmount = 0
 			worker.CurrentAction = "deposited food"
 			return
 		}
 
-		// Move toward queen using dedicated function
-		worker.CurrentAction = fmt.Sprintf("bringing %d food to queen", worker.FoodAmount)
+		// Lay a recruitment trail back to the food for nestmates to follow
+		layPheromone(world, worker.Ant, types.Trail, trailDeposit)
+
+		// Move toward the stores using dedicated function
+		worker.CurrentAction = fmt.Sprintf("bringing %d food home", worker.FoodAmount)
 		if !workerPathfinder.BringFoodToQueen(world, colony, worker) {
 			worker.CurrentAction = "stuck with food"
 		}
 		return
 	}
 
-	// Check current cell for food
+	// A dead nestmate goes out to the midden before anything else
+	if worker.CarryingCorpse {
+		carryToMidden(world, colony, worker)
+		return
+	}
+
+	// A hungry worker goes home to eat before heading out again
+	if seekMeal(world, colony, worker) {
+		return
+	}
+
+	// A few free workers carry the stores over while they are being moved
+	if haulStores(world, colony, worker) {
+		return
+	}
+
+	// An idle worker stands over the herd while rivals or spiders are about
+	if guardHerd(world, colony, worker) {
+		return
+	}
+
+	// An idle worker looks after the fungus garden when it needs care
+	if tendGarden(world, colony, worker) {
+		return
+	}
+
+	// Bodies lying nearby are cleared out of the way
+	if collectCorpse(world, colony, worker) {
+		return
+	}
+
+	// Check current cell for food the colony wants. One short of a kind of
+	// food leaves what it has plenty of lying for later
+	wanted := wantedFood(colony)
 	currentCell := world.GetCell(worker.Position.X, worker.Position.Y)
-	if currentCell != nil && currentCell.Food > 0 {
+	if currentCell != nil && currentCell.Food > 0 && wants(wanted, currentCell.FoodType) {
 		worker.CarryingFood = true
 		worker.FoodAmount = 10 * types.FoodScale
+		worker.FoodType = currentCell.FoodType
 		currentCell.Food = 0
+		currentCell.Rot = 0
+		noteFoodSite(world, worker)
 		worker.CurrentAction = "picked up food"
 		return
 	}
 
 	// Check if on surface (grass) - grass gives 5 food and disappears
-	if worker.Position.Y == 1 && currentCell != nil && currentCell.Soil == types.Empty {
+	if worker.Position.Y == 1 && currentCell != nil && currentCell.Soil == types.Empty && wants(wanted, types.Carbohydrate) {
 		// Check if this cell has grass (not already harvested)
 		// We'll use Food = -1 to mark harvested grass
 		if currentCell.Food >= 0 {
 			worker.CarryingFood = true
 			worker.FoodAmount = 5 * types.FoodScale
+			worker.FoodType = types.Carbohydrate
+			worker.CarryingGrass = true
 			currentCell.Food = -1 // Mark as harvested (no more grass)
+			noteFoodSite(world, worker)
 			worker.CurrentAction = "foraged grass"
 			return
 		}
 	}
 
-	// Move randomly like a real ant (continues in same direction for several moves)
-	if workerPathfinder.MoveRandomly(world, worker) {
-		worker.CurrentAction = "exploring"
-	} else {
+	// A worker on digging duty extends the nest rather than going out for more
+	if worker.Task == types.Digging && excavate(world, colony, worker) {
+		return
+	}
+
+	// Milk an aphid within reach, or head for one the colony herds
+	if wants(wanted, types.Sugar) && (milkAphid(world, colony, worker) || visitHerd(world, colony, worker)) {
+		return
+	}
+
+	// Follow the smell of food lying nearby
+	if workerPathfinder.FollowScent(world, worker, wanted...) {
+		worker.CurrentAction = "following food scent"
+		return
+	}
+
+	// Go back to where food was left last time. Sites are not told apart by
+	// what was there, so a worker after one kind of food does without them
+	if len(wanted) == len(everyFood) && returnToFoodSite(world, colony, worker) {
+		return
+	}
+
+	// Head out the way the colony's workers explore: by default along a
+	// nestmate's trail, otherwise wandering like a real ant
+	if !pathfinder.MoverFor(colony, types.Worker).Explore(world, colony, worker, trailFollowThreshold) {
 		worker.CurrentAction = "resting"
 	}
 }
 
 // nurseBehavior defines how nurse ants act
-// Nurses guard the nursery and tend to larvae
+// Nurses take charge of a larvae, fetch food for it from the colony stores and
+// feed it whenever it is hungry. Each feeding grows the larvae by the nurse's
+// NursingSpeed; a larvae nobody feeds stops growing.
 func nurseBehavior(world *types.World, colony *types.Colony, nurse *types.NurseAnt) {
-	// FIRST: If already nursing a larvae, stick with it until it becomes a worker
-	if nurse.CurrentlyNursing != nil {
-		// Check if this larvae still exists
-		for _, larvae := range colony.Larvae {
-			if larvae.ID == nurse.CurrentlyNursing.ID {
-				// Check if adjacent
-				if nursePathfinder.IsAdjacentToLarvae(nurse, larvae.Position) {
-					// Adjacent - keep nursing
-					nurse.CurrentAction = fmt.Sprintf("taking care of larvae #%d", larvae.ID)
-					return
-				} else {
-					// Not adjacent anymore - larvae might have moved or we got pushed
-					// Clear and re-acquire
-					nurse.CurrentlyNursing = nil
-					break
-				}
-			}
-		}
-		// Larvae no longer exists (became a worker), clear it
+	// FIRST: If already nursing a larvae, stick with it until it matures
+	if nurse.CurrentlyNursing != nil && !hasLarvae(colony, nurse.CurrentlyNursing) {
+		// Larvae no longer exists (matured or died), clear it
 		nurse.CurrentlyNursing = nil
 	}
 
-	// SECOND: Find nearest larvae that needs care
-	var targetLarvae *types.LarvaeAnt
-	minDist := 9999
-
-	for _, larvae := range colony.Larvae {
-		if !larvae.HasNurseCare {
-			// Make sure no other nurse is already nursing this larvae
-			alreadyTargeted := false
-			if colony.HeadNurse != nil && colony.HeadNurse != nurse &&
-				colony.HeadNurse.CurrentlyNursing != nil &&
-				colony.HeadNurse.CurrentlyNursing.ID == larvae.ID {
-				alreadyTargeted = true
-			}
-			if !alreadyTargeted {
-				for _, otherNurse := range colony.Nurses {
-					if otherNurse != nurse && otherNurse.CurrentlyNursing != nil &&
-						otherNurse.CurrentlyNursing.ID == larvae.ID {
-						alreadyTargeted = true
-						break
-					}
+	// SECOND: Take charge of the nearest larvae nobody is caring for. The head
+	// nurse hands larvae out herself, so under her a nurse only waits for one
+	if nurse.CurrentlyNursing == nil {
+		var targetLarvae *types.LarvaeAnt
+		if colony.HeadNurse == nil {
+			minDist := 9999
+			for _, larvae := range colony.Larvae {
+				if larvae.HasNurseCare {
+					continue
 				}
-			}
-
-			if !alreadyTargeted {
 				dist := util.Abs(larvae.Position.X-nurse.Position.X) + util.Abs(larvae.Position.Y-nurse.Position.Y)
 				if dist < minDist {
 					minDist = dist
@@ -148,30 +276,81 @@ func nurseBehavior(world *types.World, colony *types.Colony, nurse *types.NurseA
 				}
 			}
 		}
+
+		// No larvae need care - help with the fungus garden or guard the nursery
+		if targetLarvae == nil {
+			if tendGarden(world, colony, nurse) {
+				return
+			}
+			if nursePathfinder.GuardNursery(world, colony, nurse) {
+				nurse.CurrentAction = "guarding nursery"
+			} else {
+				nurse.CurrentAction = "moving to nursery"
+			}
+			return
+		}
+
+		targetLarvae.HasNurseCare = true
+		nurse.CurrentlyNursing = targetLarvae
 	}
 
-	// No larvae need care - guard the nursery
-	if targetLarvae == nil {
-		if nursePathfinder.GuardNursery(world, colony, nurse) {
-			nurse.CurrentAction = "guarding nursery"
+	larvae := nurse.CurrentlyNursing
+
+	// Empty-handed - fetch a ration from the stores before going to the larvae
+	// Larvae only grow on the brood diet, so no protein means no ration
+	if nurse.FoodAmount == 0 && colony.Food.Available(broodDiet...) >= larvaeMealCost {
+		store := colony.StorePosition()
+		if pathfinder.IsAdjacentOrSame(nurse.Position, store) {
+			nurse.FoodAmount = colony.Food.Spend(larvaeMealCost, broodDiet...)
+			nurse.CurrentAction = fmt.Sprintf("picked up food for larvae #%d", larvae.ID)
+			return
+		}
+		nurse.CurrentAction = fmt.Sprintf("fetching food for larvae #%d", larvae.ID)
+		nursePathfinder.MoveTowardTarget(world, colony, nurse, store, false)
+		return
+	}
+
+	// Not adjacent - move toward larvae (going around queen if needed)
+	if !nursePathfinder.IsAdjacentToLarvae(nurse, larvae.Position) {
+		if nurse.FoodAmount > 0 {
+			nurse.CurrentAction = fmt.Sprintf("carrying food to larvae #%d", larvae.ID)
 		} else {
-			nurse.CurrentAction = "moving to nursery"
+			nurse.CurrentAction = fmt.Sprintf("going to larvae #%d", larvae.ID)
 		}
-		nurse.CurrentlyNursing = nil
+		nursePathfinder.MoveTowardLarvae(world, colony, nurse, larvae.Position)
 		return
 	}
 
-	// Check if adjacent to target larvae
-	if nursePathfinder.IsAdjacentToLarvae(nurse, targetLarvae.Position) {
-		// Adjacent - start nursing
-		targetLarvae.HasNurseCare = true
-		targetLarvae.GrowthProgress = 100
-		nurse.CurrentAction = fmt.Sprintf("taking care of larvae #%d", targetLarvae.ID)
-		nurse.CurrentlyNursing = targetLarvae
+	// Adjacent - feed the larvae once it is hungry, otherwise watch over it
+	if nurse.FoodAmount > 0 && larvae.Hunger >= larvaeFeedingHunger {
+		feedLarvae(nurse, larvae)
+		nurse.CurrentAction = fmt.Sprintf("fed larvae #%d", larvae.ID)
 		return
 	}
+	// Larvae lying outside the nursery are carried back to it
+	if settleBrood(world, colony, nurse) {
+		return
+	}
+	nurse.CurrentAction = fmt.Sprintf("taking care of larvae #%d", larvae.ID)
+}
+
+// feedLarvae hands the nurse's ration to a larvae, growing it by the nurse's NursingSpeed
+func feedLarvae(nurse *types.NurseAnt, larvae *types.LarvaeAnt) {
+	nurse.FoodAmount = 0
+	larvae.Hunger = 0
+	larvae.Starving = false
+	larvae.GrowthProgress += larvaeGrowthPerFeeding * nurse.NursingSpeed
+	if larvae.GrowthProgress > types.LarvaeMatureProgress {
+		larvae.GrowthProgress = types.LarvaeMatureProgress
+	}
+}
 
-	// Not adjacent - move toward larvae (going around queen if needed)
-	nurse.CurrentAction = fmt.Sprintf("going to larvae #%d", targetLarvae.ID)
-	nursePathfinder.MoveTowardLarvae(world, colony, nurse, targetLarvae.Position)
+// hasLarvae checks whether a larvae is still part of the colony
+func hasLarvae(colony *types.Colony, larvae *types.LarvaeAnt) bool {
+	for _, l := range colony.Larvae {
+		if l.ID == larvae.ID {
+			return true
+		}
+	}
+	return false
 }
@@ -72,6 +72,44 @@ func TestWorkerPicksUpFood(t *testing.T) {
 	}
 }
 
+func TestWantedFoodFollowsShortfall(t *testing.T) {
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+
+	colony.Food = types.FoodStore{types.Carbohydrate: adultReserve, types.Protein: broodReserve - 1}
+	if got := wantedFood(colony); len(got) != 1 || got[0] != types.Protein {
+		t.Errorf("Short of protein only, foragers should want protein, got %v", got)
+	}
+	colony.Food = types.FoodStore{types.Carbohydrate: adultReserve - 1, types.Protein: broodReserve}
+	if got := wantedFood(colony); wants(got, types.Protein) || !wants(got, types.Carbohydrate) {
+		t.Errorf("Short of adult food only, foragers should want it, got %v", got)
+	}
+	colony.Food = types.FoodStore{}
+	if got := wantedFood(colony); len(got) != len(everyFood) {
+		t.Errorf("Short of everything, foragers should take anything, got %v", got)
+	}
+}
+
+func TestWorkerShortOfProteinLeavesSeeds(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	colony.Food = types.FoodStore{types.Carbohydrate: 100 * types.FoodScale}
+
+	worker := SpawnWorker(colony, 10, 1)
+	world.GetCell(10, 1).Food = 5
+	world.GetCell(10, 1).FoodType = types.Carbohydrate
+	PlaceAnt(world, worker)
+
+	workerBehavior(world, colony, worker)
+
+	if worker.CarryingFood {
+		t.Error("A colony with seeds to spare but no protein should not send seeds home")
+	}
+	if world.GetCell(10, 1).Food != 5 {
+		t.Error("The seeds should be left lying")
+	}
+}
+
 func TestWorkerDepositsFood(t *testing.T) {
 	world := types.NewWorld(40, 30, random.New(1))
 	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
@@ -84,15 +122,15 @@ func TestWorkerDepositsFood(t *testing.T) {
 	world.GetCell(21, 15).IsTunnel = true
 	PlaceAnt(world, worker)
 
-	initialFood := colony.Food
+	initialFood := colony.Food.Total()
 
 	UpdateWorld(world)
 
 	if worker.CarryingFood {
 		t.Error("Worker should have deposited food")
 	}
-	if colony.Food != initialFood+10 {
-		t.Errorf("Colony food should increase by 10, got %d", colony.Food)
+	if colony.Food.Total() != initialFood+10 {
+		t.Errorf("Colony food should increase by 10, got %d", colony.Food.Total())
 	}
 }
 
@@ -113,3 +151,134 @@ func TestLarvaeAges(t *testing.T) {
 		t.Errorf("Larvae age should increment, got %d", larvae.Age)
 	}
 }
+
+func TestNurseFetchesFoodForLarvae(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+
+	larvae := SpawnLarvae(colony, 22, 15)
+	world.GetCell(22, 15).IsTunnel = true
+	PlaceAnt(world, larvae)
+
+	initialProtein := colony.Food[types.Protein]
+	nurse := colony.HeadNurse // Starts next to the queen, on top of the stores
+
+	UpdateWorld(world)
+
+	if nurse.CurrentlyNursing == nil || nurse.CurrentlyNursing.ID != larvae.ID {
+		t.Fatal("Nurse should have taken charge of the larvae")
+	}
+	if nurse.FoodAmount != larvaeMealCost {
+		t.Errorf("Expected nurse to carry %d food, got %d", larvaeMealCost, nurse.FoodAmount)
+	}
+	if colony.Food[types.Protein] != initialProtein-larvaeMealCost {
+		t.Errorf("Expected protein to drop by %d, got %d", larvaeMealCost, initialProtein-colony.Food[types.Protein])
+	}
+}
+
+func TestNurseFeedingGrowsLarvaeByNursingSpeed(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+
+	larvae := SpawnLarvae(colony, 22, 15)
+	world.GetCell(22, 15).IsTunnel = true
+	PlaceAnt(world, larvae)
+	larvae.Hunger = larvaeFeedingHunger
+
+	nurse := colony.HeadNurse
+	nurse.NursingSpeed = 2
+	nurse.FoodAmount = larvaeMealCost
+
+	UpdateWorld(world)
+
+	if larvae.GrowthProgress != 2*larvaeGrowthPerFeeding {
+		t.Errorf("Expected growth %d, got %d", 2*larvaeGrowthPerFeeding, larvae.GrowthProgress)
+	}
+	if larvae.Hunger != 0 {
+		t.Errorf("Fed larvae should not be hungry, got %d", larvae.Hunger)
+	}
+	if nurse.FoodAmount != 0 {
+		t.Error("Nurse should have handed over her ration")
+	}
+}
+
+func TestUnfedLarvaeDoesNotGrow(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	colony.Food = types.FoodStore{} // Nothing for the nurse to fetch
+	colony.Workers = nil            // and nobody out foraging to restock
+	AddColony(world, colony)
+
+	larvae := SpawnLarvae(colony, 22, 15)
+	world.GetCell(22, 15).IsTunnel = true
+	PlaceAnt(world, larvae)
+
+	for i := 0; i < 60; i++ {
+		UpdateWorld(world)
+	}
+
+	if larvae.GrowthProgress != 0 {
+		t.Errorf("Unfed larvae should not grow, got %d", larvae.GrowthProgress)
+	}
+	if len(colony.Larvae) != 1 {
+		t.Error("Unfed larvae should not mature")
+	}
+}
+
+func TestWorkerDepositsFoodByType(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+
+	worker := SpawnWorker(colony, 10, 1)
+	world.GetCell(10, 1).Food = 5
+	world.GetCell(10, 1).FoodType = types.Protein
+	PlaceAnt(world, worker)
+
+	UpdateWorld(world)
+
+	if !worker.CarryingFood || worker.FoodType != types.Protein {
+		t.Fatalf("Worker should be carrying protein, got %d", worker.FoodType)
+	}
+
+	// Bring it back to the stores and drop it off
+	store := colony.StorePosition()
+	world.GetCell(worker.Position.X, worker.Position.Y).Occupant = nil
+	worker.Position = types.Position{X: store.X + 1, Y: store.Y}
+	world.GetCell(store.X+1, store.Y).IsTunnel = true
+	PlaceAnt(world, worker)
+	protein, carbohydrate := colony.Food[types.Protein], colony.Food[types.Carbohydrate]
+	amount := worker.FoodAmount
+
+	workerBehavior(world, colony, worker)
+
+	if worker.CarryingFood {
+		t.Fatal("Worker beside the stores should have deposited its food")
+	}
+	if colony.Food[types.Protein] != protein+amount {
+		t.Errorf("Expected protein to rise by %d to %d, got %d", amount, protein+amount, colony.Food[types.Protein])
+	}
+	if colony.Food[types.Carbohydrate] != carbohydrate {
+		t.Error("Depositing protein should not change the carbohydrate store")
+	}
+}
+
+func TestWorkerBesideQueenFixesHomeVector(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+
+	worker := SpawnWorker(colony, 21, 15)
+	worker.HomeVector = types.Position{X: 7, Y: -3}
+	worker.Travelled = 50
+
+	updateWorker(world, colony, worker)
+	workerPathfinder.IntegratePath(world, worker)
+
+	// The worker may have stepped off since, but its vector is true again
+	if home := worker.ReckonedHome(); home != colony.QueenPosition {
+		t.Errorf("Expected worker to reckon home at the queen, got (%d,%d)", home.X, home.Y)
+	}
+}
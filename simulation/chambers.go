@@ -0,0 +1,207 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+)
+
+// chambers.go - Planning the nest and keeping things in their rooms
+// A colony plans its nest around the queen: her own chamber, a nursery and a
+// granary to either side, a gallery from her chamber to each of them and a
+// shaft up to the surface, with the midden sited outside. Ants on digging duty
+// dig out whatever of the plan is still soil before extending the nest
+// anywhere else. Chambers widen as the colony grows. Once dug, larvae hatch in
+// the nursery and nurses carry strays back to it, and the stores move to the
+// granary. The granary goes in the driest spot on offer.
+
+// Nest plan tuning
+var (
+	chamberHalfWidth = 1  // Cells either side of a new chamber's centre
+	maxHalfWidth     = 3  // Widest a chamber grows, either side of its centre
+	antsPerWidening  = 10 // Ants the colony needs for each extra cell of width
+	replanDistance   = 6  // How far the queen can move before the nest is planned afresh
+	planCall         = 5  // Call to dig for each planned cell still soil
+)
+
+// chamberOffsets are the spots around the queen, nearest first, where a
+// colony tries to put its nursery and granary
+var chamberOffsets = [][2]int{
+	{-5, 0}, {5, 0}, {-5, 2}, {5, 2}, {0, 5}, {-4, 4}, {4, 4},
+}
+
+// planNest lays out the colony's nest if it has no plan or the queen has
+// moved far from the one it has, then keeps the plan up to date: the queen's
+// chamber follows her, chambers widen with the population, and each is marked
+// open once a tunnel joins its centre to the queen
+func planNest(world *types.World, colony *types.Colony) {
+	if colony.Queen == nil {
+		return
+	}
+	queenChamber := colony.Chamber(types.QueenChamber)
+	if queenChamber == nil || pathfinder.ManhattanDistance(queenChamber.Center, colony.QueenPosition) > replanDistance {
+		layOutNest(world, colony)
+		queenChamber = colony.Chamber(types.QueenChamber)
+	}
+	queenChamber.Center = colony.QueenPosition
+
+	width := min(maxHalfWidth, chamberHalfWidth+colony.GetAntCount()/antsPerWidening)
+	for _, chamber := range colony.Chambers {
+		if (chamber.Kind == types.Nursery || chamber.Kind == types.Granary) && chamber.HalfWidth < width {
+			widen(world, colony, chamber, width)
+		}
+		chamber.Open = colony.HomeField != nil &&
+			colony.HomeField.At(chamber.Center.X, chamber.Center.Y) != types.Unreachable
+	}
+}
+
+// layOutNest plans the queen's chamber, a nursery and a granary, with a
+// gallery to each and a shaft up to the surface, and lists the midden with
+// them, siting it first if the colony has none
+func layOutNest(world *types.World, colony *types.Colony) {
+	queen := colony.QueenPosition
+	colony.Chambers = []*types.Chamber{
+		types.NewChamber(types.QueenChamber, queen.X, queen.Y, 1, 1),
+	}
+	colony.Galleries = []types.Gallery{
+		{From: queen, To: types.Position{X: queen.X, Y: surfaceRow + 1}},
+	}
+
+	for _, kind := range []types.ChamberKind{types.Nursery, types.Granary} {
+		var site *types.Chamber
+		for _, offset := range chamberOffsets {
+			chamber := types.NewChamber(kind, queen.X+offset[0], queen.Y+offset[1], chamberHalfWidth, 1)
+			if !fits(world, colony, chamber) {
+				continue
+			}
+			// The nursery takes the nearest spot, the granary the driest
+			if site == nil || moistureAt(world, chamber.Center) < moistureAt(world, site.Center) {
+				site = chamber
+			}
+			if kind != types.Granary {
+				break
+			}
+		}
+		if site != nil {
+			colony.Chambers = append(colony.Chambers, site)
+			colony.Galleries = append(colony.Galleries, types.Gallery{From: queen, To: site.Center})
+		}
+	}
+	colony.Stores = nil
+	colony.Haul = nil
+
+	middenFor(world, colony)
+}
+
+// fits reports whether a planned chamber lies underground inside the world,
+// is centred on soil that can be dug, and keeps clear of the colony's other
+// chambers and its fungus garden
+func fits(world *types.World, colony *types.Colony, chamber *types.Chamber) bool {
+	for _, pos := range chamber.Cells() {
+		if !world.IsValidPosition(pos.X, pos.Y) || pos.Y <= surfaceRow+1 {
+			return false
+		}
+		if colony.Garden != nil && pos == colony.Garden.Position {
+			return false
+		}
+		for _, other := range colony.Chambers {
+			if other != chamber && other.Contains(pos) {
+				return false
+			}
+		}
+	}
+	center := world.GetCell(chamber.Center.X, chamber.Center.Y)
+	return center != nil && center.Soil != types.Rock
+}
+
+// widen grows a chamber out toward a half width, a cell at a time, stopping
+// where it would run into something
+func widen(world *types.World, colony *types.Colony, chamber *types.Chamber, width int) {
+	for chamber.HalfWidth < width {
+		chamber.HalfWidth++
+		if !fits(world, colony, chamber) {
+			chamber.HalfWidth--
+			return
+		}
+	}
+}
+
+// plannedDigs lists every cell of the colony's plan that is still soil, the
+// chambers first and then the galleries
+func plannedDigs(world *types.World, colony *types.Colony) []types.Position {
+	var digs []types.Position
+	add := func(pos types.Position) {
+		if pos.Y > surfaceRow && pathfinder.CanDigTo(world, pos.X, pos.Y) {
+			digs = append(digs, pos)
+		}
+	}
+	for _, chamber := range colony.Chambers {
+		for _, pos := range chamber.Cells() {
+			add(pos)
+		}
+	}
+	for _, gallery := range colony.Galleries {
+		for _, pos := range gallery.Cells() {
+			add(pos)
+		}
+	}
+	return digs
+}
+
+// digPlan has a digging worker dig out the nearest planned cell still soil,
+// or walk toward it. Returns false if the plan is all dug
+func digPlan(world *types.World, colony *types.Colony, worker *types.WorkerAnt) bool {
+	digs := plannedDigs(world, colony)
+	if len(digs) == 0 {
+		return false
+	}
+
+	nearest := digs[0]
+	for _, pos := range digs[1:] {
+		if pathfinder.ManhattanDistance(worker.Position, pos) < pathfinder.ManhattanDistance(worker.Position, nearest) {
+			nearest = pos
+		}
+	}
+
+	if pathfinder.IsAdjacent(worker.Position, nearest) {
+		worker.CurrentAction = "digging out the nest"
+		return pathfinder.DigAndMove(world, worker, nearest.X, nearest.Y)
+	}
+	worker.CurrentAction = "going to dig"
+	return pathfinder.MoverFor(colony, types.Worker).Toward(world, colony, worker, nearest)
+}
+
+// hatchPosition finds a free cell for a new larvae: in the nursery once it has
+// been dug, otherwise beside the queen
+func hatchPosition(world *types.World, colony *types.Colony) (int, int) {
+	if nursery := colony.Chamber(types.Nursery); nursery != nil && nursery.Open {
+		if x, y := findEmptySpawnPosition(world, nursery.Center); x != -1 {
+			return x, y
+		}
+	}
+	return findEmptySpawnPosition(world, colony.QueenPosition)
+}
+
+// settleBrood has a nurse beside her larvae carry it a step toward the
+// nursery if it is lying outside. Returns false if the larvae is where it
+// belongs or there is no open nursery
+func settleBrood(world *types.World, colony *types.Colony, nurse *types.NurseAnt) bool {
+	nursery := colony.Chamber(types.Nursery)
+	larvae := nurse.CurrentlyNursing
+	if nursery == nil || !nursery.Open || larvae == nil || nursery.Contains(larvae.Position) {
+		return false
+	}
+
+	pos := larvae.Position
+	dist := pathfinder.ManhattanDistance(pos, nursery.Center)
+	for _, dir := range pathfinder.GetAllDirections() {
+		dx, dy := pathfinder.DirectionToOffset(dir)
+		x, y := pos.X+dx, pos.Y+dy
+		if pathfinder.ManhattanDistance(types.Position{X: x, Y: y}, nursery.Center) < dist &&
+			pathfinder.CanMoveTo(world, x, y) {
+			pathfinder.Move(world, larvae, x, y)
+			nurse.CurrentAction = "carrying larvae to the nursery"
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+// digOut opens up every cell of a chamber and lets the colony see it
+func digOut(world *types.World, colony *types.Colony, chamber *types.Chamber) {
+	gallery := types.Gallery{From: colony.QueenPosition, To: chamber.Center}
+	for _, pos := range gallery.Cells() {
+		world.GetCell(pos.X, pos.Y).IsTunnel = true
+	}
+	for _, pos := range chamber.Cells() {
+		world.GetCell(pos.X, pos.Y).IsTunnel = true
+	}
+	colony.HomeField = types.NewDistanceField(world, colony.QueenPosition)
+}
+
+func TestPlanNestLaysOutChambers(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+
+	planNest(world, colony)
+
+	for _, kind := range []types.ChamberKind{types.QueenChamber, types.Nursery, types.Granary} {
+		if colony.Chamber(kind) == nil {
+			t.Errorf("Expected a %s in the plan", kind)
+		}
+	}
+	if queen := colony.Chamber(types.QueenChamber); queen != nil && queen.Center != colony.QueenPosition {
+		t.Errorf("The queen's chamber should be centred on her, got %v", queen.Center)
+	}
+	if len(colony.Galleries) != 3 {
+		t.Errorf("Expected a shaft and a gallery to each chamber, got %d", len(colony.Galleries))
+	}
+	if colony.Midden == nil || colony.Chamber(types.MiddenChamber) != colony.Midden.Chamber {
+		t.Error("Expected the midden to be sited with the plan and listed with the chambers")
+	}
+	if colony.Chamber(types.Granary).Open || colony.StorePosition() != colony.QueenPosition {
+		t.Error("An undug granary should not hold the stores")
+	}
+}
+
+func TestChambersWidenWithPopulation(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	planNest(world, colony)
+	nursery := colony.Chamber(types.Nursery)
+	before := nursery.HalfWidth
+
+	for i := 0; i < antsPerWidening; i++ {
+		SpawnWorker(colony, 0, 0)
+	}
+	planNest(world, colony)
+
+	if nursery.HalfWidth != before+1 {
+		t.Errorf("Expected the nursery to widen to %d, got %d", before+1, nursery.HalfWidth)
+	}
+	if queen := colony.Chamber(types.QueenChamber); queen.HalfWidth != 1 {
+		t.Errorf("The queen's chamber should keep its size, got %d", queen.HalfWidth)
+	}
+}
+
+func TestPlanNestFollowsTheQueen(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	planNest(world, colony)
+	nursery := colony.Chamber(types.Nursery)
+
+	colony.QueenPosition = types.Position{X: 20, Y: 15 + replanDistance + 2}
+	planNest(world, colony)
+
+	if colony.Chamber(types.Nursery) == nursery {
+		t.Error("The nest should be planned afresh once the queen has moved far")
+	}
+	if colony.Chamber(types.QueenChamber).Center != colony.QueenPosition {
+		t.Error("The queen's chamber should be around her new position")
+	}
+}
+
+func TestDigPlanDigsPlannedCell(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	planNest(world, colony)
+	worker := colony.Workers[0]
+	before := len(plannedDigs(world, colony))
+	if before == 0 {
+		t.Fatal("A new nest should have cells left to dig")
+	}
+
+	for i := 0; i < 5; i++ {
+		if !digPlan(world, colony, worker) {
+			t.Fatal("The worker should dig or head for the plan")
+		}
+	}
+
+	if after := len(plannedDigs(world, colony)); after >= before {
+		t.Errorf("Expected fewer planned cells left to dig, had %d and now %d", before, after)
+	}
+}
+
+func TestHatchPositionUsesOpenNursery(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	planNest(world, colony)
+	nursery := colony.Chamber(types.Nursery)
+
+	x, y := hatchPosition(world, colony)
+	if nursery.Contains(types.Position{X: x, Y: y}) {
+		t.Error("Larvae should not hatch in a nursery that has not been dug")
+	}
+
+	digOut(world, colony, nursery)
+	planNest(world, colony)
+	x, y = hatchPosition(world, colony)
+	if !nursery.Contains(types.Position{X: x, Y: y}) {
+		t.Errorf("Expected larvae to hatch in the nursery, got (%d,%d)", x, y)
+	}
+}
+
+func TestSettleBroodCarriesLarvaeToNursery(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	planNest(world, colony)
+	nursery := colony.Chamber(types.Nursery)
+	digOut(world, colony, nursery)
+	planNest(world, colony)
+
+	// Lying in the gallery a couple of cells short of the nursery
+	x := nursery.Center.X + nursery.HalfWidth + 2
+	if nursery.Center.X > colony.QueenPosition.X {
+		x = nursery.Center.X - nursery.HalfWidth - 2
+	}
+	larvae := SpawnLarvae(colony, x, colony.QueenPosition.Y)
+	if !PlaceAnt(world, larvae) {
+		t.Fatalf("Could not place the larvae at (%d,%d)", x, colony.QueenPosition.Y)
+	}
+	nurse := colony.HeadNurse
+	nurse.CurrentlyNursing = larvae
+	dist := pathfinder.ManhattanDistance(larvae.Position, nursery.Center)
+
+	if !settleBrood(world, colony, nurse) {
+		t.Fatal("The nurse should carry the larvae toward the nursery")
+	}
+	if pathfinder.ManhattanDistance(larvae.Position, nursery.Center) >= dist {
+		t.Errorf("Expected the larvae closer to the nursery, at %v", larvae.Position)
+	}
+	if world.GetCell(larvae.Position.X, larvae.Position.Y).Occupant != larvae {
+		t.Error("The larvae's new cell should hold it")
+	}
+
+	for settleBrood(world, colony, nurse) {
+	}
+	if !nursery.Contains(larvae.Position) {
+		t.Errorf("Expected the larvae to end up in the nursery, at %v", larvae.Position)
+	}
+}
+
+func TestWorkerDepositsAtOpenGranary(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	planNest(world, colony)
+	granary := colony.Chamber(types.Granary)
+	digOut(world, colony, granary)
+	planNest(world, colony)
+	colony.Food = types.FoodStore{} // Nothing to carry, so the stores move at once
+	moveStores(world, colony)
+
+	store := colony.StorePosition()
+	if store != granary.Center {
+		t.Fatalf("Expected the stores in the granary, got %v", store)
+	}
+	worker := SpawnWorker(colony, store.X+1, store.Y)
+	PlaceAnt(world, worker)
+	worker.CarryingFood = true
+	worker.FoodType = types.Carbohydrate
+	worker.FoodAmount = 3
+	before := colony.Food[types.Carbohydrate]
+
+	workerBehavior(world, colony, worker)
+
+	if worker.CarryingFood || colony.Food[types.Carbohydrate] != before+3 {
+		t.Error("A worker beside the granary should deposit its food there")
+	}
+}
+
+func TestGranaryGoesInTheDriestSpot(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	world.GetCell(25, 15).Moisture = 80 // The nearest spot on the granary's side is sodden
+
+	planNest(world, colony)
+
+	granary := colony.Chamber(types.Granary)
+	if granary == nil {
+		t.Fatal("Expected a granary in the plan")
+	}
+	if granary.Center == (types.Position{X: 25, Y: 15}) {
+		t.Error("The granary should not be planned in sodden ground")
+	}
+	if nursery := colony.Chamber(types.Nursery); nursery.Center != (types.Position{X: 15, Y: 15}) {
+		t.Errorf("The nursery should still take the nearest spot, got %v", nursery.Center)
+	}
+}
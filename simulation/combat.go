@@ -0,0 +1,184 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+	"fmt"
+)
+
+// combat.go - Fights between colonies and with predators
+// An adult next to a spider or an ant of another colony may attack it. A blow
+// does the attacker's power less the defender's defence, give or take a roll
+// of the world's dice, and never less than 1. Soldiers always fight and use
+// their own AttackPower and DefenseBonus. Workers and nurses bite weakly and
+// only some of the time, and leave queens and brood alone. A soldier next to
+// enemy larvae eats them, and given long enough will kill a queen. Any ant
+// that is hit raises the alarm, and every kill is logged as an event.
+
+// Combat tuning
+var (
+	workerAttack    = 6   // Damage of a worker's bite before the roll
+	nurseAttack     = 4   // Damage of a nurse's bite before the roll
+	weakFightChance = 50  // Percent chance a worker or nurse bites an enemy next to it
+	queenDefense    = 5   // Damage a queen shrugs off per blow
+	combatSpread    = 5   // Damage rolls up to this much either side
+	larvaeMeal      = 200 // Hunger a soldier sates by eating enemy larvae
+	spiderAttack    = 15  // Damage of a spider's bite before the roll
+)
+
+// attackPower returns the damage an ant's blow does before the roll
+func attackPower(ant types.AntInterface) int {
+	switch a := ant.(type) {
+	case *types.SoldierAnt:
+		return a.AttackPower
+	case *types.WorkerAnt:
+		return workerAttack
+	case *types.NurseAnt:
+		return nurseAttack
+	}
+	return 0
+}
+
+// defense returns the damage an ant shrugs off per blow
+func defense(ant types.AntInterface) int {
+	switch a := ant.(type) {
+	case *types.SoldierAnt:
+		return a.DefenseBonus
+	case *types.QueenAnt:
+		return queenDefense
+	}
+	return 0
+}
+
+// blow rolls the damage of one blow
+func blow(world *types.World, power, defense int) int {
+	roll := int(world.Random.Range(int32(-combatSpread), int32(combatSpread+1)))
+	return max(1, power-defense+roll)
+}
+
+// describe names an ant for the event log, such as "Red Soldier 12"
+func describe(ant *types.Ant) string {
+	return fmt.Sprintf("%s %s %d", ant.ColonyID, ant.Role, ant.ID)
+}
+
+// fight has an ant attack whatever is next to it
+// A spider comes first, then enemy adults, then for a soldier an enemy queen
+// and then enemy larvae. Within each, the first in direction order. Returns
+// true if the ant spent its tick fighting
+func fight(world *types.World, colony *types.Colony, ant types.AntInterface) bool {
+	attacker := ant.GetAnt()
+	if attacker.Health <= 0 {
+		return false
+	}
+
+	var spider *types.Critter
+	var adult, queen, larvae types.AntInterface
+	for _, dir := range pathfinder.GetAllDirections() {
+		dx, dy := pathfinder.DirectionToOffset(dir)
+		cell := world.GetCell(attacker.Position.X+dx, attacker.Position.Y+dy)
+		if cell == nil {
+			continue
+		}
+		if cell.Critter != nil && cell.Critter.Kind == types.Spider && !cell.Critter.IsDead() && spider == nil {
+			spider = cell.Critter
+		}
+		other := cell.Occupant
+		if other == nil || other.GetAnt().ColonyID == attacker.ColonyID || other.GetAnt().Health <= 0 {
+			continue
+		}
+		switch other.GetRole() {
+		case types.Queen:
+			if queen == nil {
+				queen = other
+			}
+		case types.Larvae:
+			if larvae == nil {
+				larvae = other
+			}
+		default:
+			if adult == nil {
+				adult = other
+			}
+		}
+	}
+
+	// Only roll for a weak bite once there is something to bite, so an ant
+	// with nobody beside it never touches the dice
+	soldier := attacker.Role == types.Soldier
+	if spider == nil && adult == nil && (!soldier || (queen == nil && larvae == nil)) {
+		return false
+	}
+	if !soldier && !world.Random.Chance(uint32(weakFightChance)) {
+		return false
+	}
+
+	switch {
+	case spider != nil:
+		attacker.CurrentAction = "fighting spider"
+		spider.Health -= blow(world, attackPower(ant), 0)
+		if spider.IsDead() {
+			colony.Kills++
+			world.ReportEvent("%s killed a spider", describe(attacker))
+		}
+	case adult != nil:
+		attacker.CurrentAction = fmt.Sprintf("fighting %s ant", adult.GetAnt().ColonyID)
+		strike(world, colony, ant, adult)
+	case soldier && queen != nil:
+		attacker.CurrentAction = fmt.Sprintf("attacking %s queen", queen.GetAnt().ColonyID)
+		strike(world, colony, ant, queen)
+	case soldier && larvae != nil:
+		attacker.CurrentAction = fmt.Sprintf("eating %s larvae", larvae.GetAnt().ColonyID)
+		eaten := larvae.GetAnt()
+		eaten.Health = 0
+		eaten.Slain = true
+		attacker.Hunger = max(0, attacker.Hunger-larvaeMeal)
+		colony.Kills++
+		world.ReportEvent("%s ate %s", describe(attacker), describe(eaten))
+	}
+	return true
+}
+
+// strike lands one blow on an enemy ant, which raises the alarm and reports
+// the attacker to its colony
+func strike(world *types.World, colony *types.Colony, attacker, defender types.AntInterface) {
+	victim := defender.GetAnt()
+	victim.Health -= blow(world, attackPower(attacker), defense(defender))
+	wounded(world, victim, attacker.GetAnt().Position)
+	if victim.Health <= 0 {
+		victim.Slain = true
+		colony.Kills++
+		world.ReportEvent("%s killed %s", describe(attacker.GetAnt()), describe(victim))
+	}
+}
+
+// wounded raises the alarm for an ant that has just been hit and tells its
+// colony where the attack came from
+func wounded(world *types.World, victim *types.Ant, from types.Position) {
+	raiseAlarm(world, victim)
+	for _, c := range world.Colonies {
+		if c.Name == victim.ColonyID {
+			c.ReportThreat(from, world.Ticks)
+		}
+	}
+}
+
+// biteAnt has a spider bite an ant next to it, the first in direction
+// order. Returns true if it bit one
+func biteAnt(world *types.World, spider *types.Critter) bool {
+	for _, dir := range pathfinder.GetAllDirections() {
+		dx, dy := pathfinder.DirectionToOffset(dir)
+		cell := world.GetCell(spider.Position.X+dx, spider.Position.Y+dy)
+		if cell == nil || cell.Occupant == nil || cell.Occupant.GetAnt().Health <= 0 {
+			continue
+		}
+		victim := cell.Occupant.GetAnt()
+		victim.Health -= blow(world, spiderAttack, defense(cell.Occupant))
+		wounded(world, victim, spider.Position)
+		if victim.Health <= 0 {
+			victim.Slain = true
+			world.ReportEvent("A spider killed %s", describe(victim))
+		}
+		return true
+	}
+	return false
+}
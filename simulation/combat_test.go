@@ -0,0 +1,179 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"strings"
+	"testing"
+)
+
+func TestBlowStaysWithinSpread(t *testing.T) {
+	world := types.NewWorld(10, 10, random.New(1))
+	for i := 0; i < 200; i++ {
+		got := blow(world, 20, 10)
+		if got < 10-combatSpread || got > 10+combatSpread {
+			t.Fatalf("Blow of 20 against 10 should be 10 give or take %d, got %d", combatSpread, got)
+		}
+	}
+	if got := blow(world, 1, 50); got != 1 {
+		t.Errorf("A blow should always do at least 1 damage, got %d", got)
+	}
+}
+
+func TestBlowIsDeterministic(t *testing.T) {
+	a := types.NewWorld(10, 10, random.New(7))
+	b := types.NewWorld(10, 10, random.New(7))
+	for i := 0; i < 50; i++ {
+		if blow(a, 20, 5) != blow(b, 20, 5) {
+			t.Fatal("Same seed should roll the same blows")
+		}
+	}
+}
+
+func TestSoldierKillsEnemyWorker(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	red := types.NewColony("Red", 20, 15, types.ColonyRed)
+	blue := types.NewColony("Blue", 30, 15, types.ColonyBlue)
+	AddColony(world, red)
+	AddColony(world, blue)
+	soldier := types.NewSoldier(1, 10, 1, "Red")
+	world.GetCell(10, 1).Occupant = soldier
+	enemy := types.NewWorker(2, 11, 1, "Blue")
+	world.GetCell(11, 1).Occupant = enemy
+	enemy.Health = 1
+
+	if !fight(world, red, soldier) {
+		t.Fatal("Soldier should fight the enemy next to it")
+	}
+	if !enemy.IsDead() || enemy.CauseOfDeath() != types.Killed {
+		t.Errorf("Enemy should have been killed, health %d", enemy.Health)
+	}
+	if red.Kills != 1 {
+		t.Errorf("Expected 1 kill, got %d", red.Kills)
+	}
+	if len(world.Events) != 1 || !strings.Contains(world.Events[0].Text, "Red Soldier 1 killed Blue Worker 2") {
+		t.Errorf("Expected the kill to be reported, got %v", world.Events)
+	}
+	if len(blue.Sightings) != 1 || blue.Sightings[0].Position != soldier.Position {
+		t.Errorf("Victim's colony should learn where the attack came from, got %v", blue.Sightings)
+	}
+	if world.Pheromones.Level("Blue", types.Alarm, 11, 1) == 0 {
+		t.Error("Being hit should raise the alarm")
+	}
+}
+
+func TestSoldierEatsEnemyLarvae(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	red := types.NewColony("Red", 20, 15, types.ColonyRed)
+	soldier := types.NewSoldier(1, 10, 5, "Red")
+	world.GetCell(10, 5).Occupant = soldier
+	soldier.Hunger = 300
+	larvae := types.NewLarvae(2, 11, 5, "Blue")
+	world.GetCell(11, 5).Occupant = larvae
+
+	if !fight(world, red, soldier) {
+		t.Fatal("Soldier should go for the enemy larvae")
+	}
+	if !larvae.IsDead() || !larvae.Slain {
+		t.Error("Larvae should have been eaten")
+	}
+	if soldier.Hunger != 300-larvaeMeal {
+		t.Errorf("Eating larvae should sate the soldier, hunger %d", soldier.Hunger)
+	}
+	if red.Kills != 1 || len(world.Events) != 1 {
+		t.Errorf("Expected the meal to count as a reported kill, kills %d, events %v", red.Kills, world.Events)
+	}
+}
+
+func TestWorkersLeaveQueensAndBroodAlone(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	red := types.NewColony("Red", 20, 15, types.ColonyRed)
+	worker := types.NewWorker(1, 10, 5, "Red")
+	world.GetCell(10, 5).Occupant = worker
+	queen := types.NewQueen(2, 11, 5, "Blue")
+	world.GetCell(11, 5).Occupant = queen
+	larvae := types.NewLarvae(3, 9, 5, "Blue")
+	world.GetCell(9, 5).Occupant = larvae
+	queenHealth, larvaeHealth := queen.Health, larvae.Health
+
+	for i := 0; i < 20; i++ {
+		if fight(world, red, worker) {
+			t.Fatal("Worker should not attack a queen or larvae")
+		}
+	}
+	if queen.Health != queenHealth || larvae.Health != larvaeHealth {
+		t.Error("Queen and larvae should be unharmed")
+	}
+}
+
+func TestWorkerWithNobodyBesideItRollsNoDice(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	red := types.NewColony("Red", 20, 15, types.ColonyRed)
+	worker := types.NewWorker(1, 10, 5, "Red")
+	world.GetCell(10, 5).Occupant = worker
+	world.GetCell(11, 5).Occupant = types.NewWorker(2, 11, 5, "Red")
+	world.GetCell(9, 5).Occupant = types.NewQueen(3, 9, 5, "Blue")
+	next := random.New(1)
+	world.Random = random.New(1)
+
+	for i := 0; i < 20; i++ {
+		fight(world, red, worker)
+	}
+	if world.Random.Below(1000) != next.Below(1000) {
+		t.Error("A worker with no enemy it would bite should not draw from the world's random source")
+	}
+}
+
+func TestNestmatesDoNotFight(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	red := types.NewColony("Red", 20, 15, types.ColonyRed)
+	soldier := types.NewSoldier(1, 10, 1, "Red")
+	world.GetCell(10, 1).Occupant = soldier
+	world.GetCell(11, 1).Occupant = types.NewWorker(2, 11, 1, "Red")
+
+	if fight(world, red, soldier) {
+		t.Error("Soldier should not attack a nestmate")
+	}
+}
+
+func TestSoldierFightsSpider(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	red := types.NewColony("Red", 20, 15, types.ColonyRed)
+	soldier := types.NewSoldier(1, 10, 1, "Red")
+	world.GetCell(10, 1).Occupant = soldier
+	spider := types.NewSpider(world.NextCritterID, 11, 1)
+	AddCritter(world, spider)
+	spider.Health = 1
+
+	if !fight(world, red, soldier) {
+		t.Fatal("Soldier should fight the spider")
+	}
+	if !spider.IsDead() || red.Kills != 1 || len(world.Events) != 1 {
+		t.Errorf("Spider should be killed and reported, health %d, events %v", spider.Health, world.Events)
+	}
+}
+
+func TestSpiderBitesAnt(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	red := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, red)
+	worker := types.NewWorker(1, 10, 1, "Red")
+	world.GetCell(10, 1).Occupant = worker
+	spider := types.NewSpider(world.NextCritterID, 11, 1)
+	AddCritter(world, spider)
+
+	health := worker.Health
+	updateSpider(world, spider)
+	if worker.Health >= health {
+		t.Fatal("Spider should bite the ant next to it")
+	}
+	if len(red.Sightings) != 1 || red.Sightings[0].Position != spider.Position {
+		t.Errorf("Colony should learn where the spider is, got %v", red.Sightings)
+	}
+
+	worker.Health = 1
+	updateSpider(world, spider)
+	if worker.CauseOfDeath() != types.Killed || len(world.Events) != 1 {
+		t.Errorf("Spider should kill the ant and report it, events %v", world.Events)
+	}
+}
@@ -0,0 +1,167 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+)
+
+// corpses.go - Dead ants, necrophoresis and the midden
+// Every ant that dies leaves its body behind, and the body blocks the cell
+// until a worker moves it. Workers carry their own dead out to the colony's
+// midden on the surface. Dead ants of other colonies are prey: workers carry
+// them home as protein. A nestmate left lying underground starts to fester
+// and infects every ant that stands next to it.
+
+// Corpse tuning
+var (
+	corpseSearchRange = 8                   // How far a worker will go to clear a body
+	corpseProtein     = 3 * types.FoodScale // Protein in the body of a rival ant
+	corpseFesterAge   = 150                 // Ticks underground before a body starts to fester
+	corpseDryAge      = 600                 // Ticks on the surface before a body dries up and is gone
+	miasmaInterval    = 10                  // Ticks between a festering body infecting its neighbours
+	corpsePathogens   = 10                  // Pathogen load a festering body passes on each time
+	middenDistance    = 8                   // How far along the surface from the queen the midden goes
+)
+
+// leaveCorpse drops the body of a dead ant in the cell where it died
+// Call it after the ant has been taken out of the world
+func leaveCorpse(world *types.World, ant *types.Ant) {
+	cell := world.GetCell(ant.Position.X, ant.Position.Y)
+	if cell == nil || cell.Corpse != nil {
+		return
+	}
+	corpse := types.NewCorpse(ant)
+	cell.Corpse = corpse
+	world.Corpses = append(world.Corpses, corpse)
+}
+
+// RemoveCorpse takes a body out of the world
+func RemoveCorpse(world *types.World, corpse *types.Corpse) {
+	cell := world.GetCell(corpse.Position.X, corpse.Position.Y)
+	if cell != nil && cell.Corpse == corpse {
+		cell.Corpse = nil
+	}
+	for i, c := range world.Corpses {
+		if c == corpse {
+			world.Corpses = append(world.Corpses[:i], world.Corpses[i+1:]...)
+			return
+		}
+	}
+}
+
+// CountCorpsesInside returns how many of a colony's dead are still lying in
+// the nest, below the surface where they fester
+func CountCorpsesInside(world *types.World, colony *types.Colony) int {
+	corpses := 0
+	for _, corpse := range world.Corpses {
+		if corpse.ColonyID == colony.Name && corpse.Position.Y > surfaceRow {
+			corpses++
+		}
+	}
+	return corpses
+}
+
+// isFestering reports whether a body has lain underground long enough to spread sickness
+func isFestering(corpse *types.Corpse) bool {
+	return corpse.Position.Y > surfaceRow && corpse.Age >= corpseFesterAge
+}
+
+// updateCorpses ages every body in the world. Festering bodies infect the ants
+// around them, and bodies on the surface dry up and disappear in time
+func updateCorpses(world *types.World) {
+	for i := len(world.Corpses) - 1; i >= 0; i-- {
+		corpse := world.Corpses[i]
+		corpse.Age++
+
+		if corpse.Position.Y <= surfaceRow && corpse.Age >= corpseDryAge {
+			RemoveCorpse(world, corpse)
+			continue
+		}
+
+		if isFestering(corpse) && world.Ticks%miasmaInterval == 0 {
+			for _, dir := range pathfinder.GetAllDirections() {
+				dx, dy := pathfinder.DirectionToOffset(dir)
+				cell := world.GetCell(corpse.Position.X+dx, corpse.Position.Y+dy)
+				if cell != nil && cell.Occupant != nil {
+					infect(cell.Occupant.GetAnt(), corpsePathogens)
+				}
+			}
+		}
+	}
+}
+
+// middenFor returns the colony's midden, founding one on the surface if it
+// does not have one yet, and makes sure it is listed with the colony's chambers
+func middenFor(world *types.World, colony *types.Colony) *types.Midden {
+	if colony.Midden == nil {
+		x := colony.QueenPosition.X + middenDistance
+		if !world.IsValidPosition(x, surfaceRow) {
+			x = colony.QueenPosition.X - middenDistance
+		}
+		if !world.IsValidPosition(x, surfaceRow) {
+			x = colony.QueenPosition.X
+		}
+		colony.Midden = types.NewMidden(x, surfaceRow)
+	}
+	if colony.Chamber(types.MiddenChamber) == nil {
+		colony.Chambers = append(colony.Chambers, colony.Midden.Chamber)
+	}
+	return colony.Midden
+}
+
+// carryToMidden walks a worker carrying a dead nestmate out to the midden and drops it there
+func carryToMidden(world *types.World, colony *types.Colony, worker *types.WorkerAnt) {
+	midden := middenFor(world, colony)
+	if pathfinder.IsAdjacentOrSame(worker.Position, midden.Center) {
+		midden.Corpses++
+		worker.CarryingCorpse = false
+		worker.CurrentAction = "dumped body on midden"
+		return
+	}
+
+	worker.CurrentAction = "carrying body to midden"
+	if !workerPathfinder.MoveTowardTarget(world, colony, worker, midden.Center) {
+		worker.CurrentAction = "stuck with body"
+	}
+}
+
+// collectCorpse sends a worker to the nearest body that needs clearing and picks it up
+// Nestmates are carried to the midden, rival ants are carried home as protein.
+// Nestmates already lying outside are left where they are. Returns false if
+// there is no body in range.
+func collectCorpse(world *types.World, colony *types.Colony, worker *types.WorkerAnt) bool {
+	var target *types.Corpse
+	minDist := corpseSearchRange + 1
+	for _, corpse := range world.Corpses {
+		if corpse.ColonyID == colony.Name && corpse.Position.Y <= surfaceRow {
+			continue
+		}
+		dist := pathfinder.ManhattanDistance(worker.Position, corpse.Position)
+		if dist < minDist {
+			minDist = dist
+			target = corpse
+		}
+	}
+	if target == nil {
+		return false
+	}
+
+	if !pathfinder.IsAdjacent(worker.Position, target.Position) {
+		worker.CurrentAction = "going to clear a body"
+		pathfinder.StepToward(world, worker, target.Position)
+		return true
+	}
+
+	RemoveCorpse(world, target)
+	if target.ColonyID == colony.Name {
+		worker.CarryingCorpse = true
+		worker.CurrentAction = "picked up dead nestmate"
+		return true
+	}
+
+	worker.CarryingFood = true
+	worker.FoodAmount = corpseProtein
+	worker.FoodType = types.Protein
+	worker.CurrentAction = "picked up dead rival"
+	return true
+}
@@ -0,0 +1,139 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestDeadWorkerLeavesCorpse(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	worker := SpawnWorker(colony, 22, 15)
+	PlaceAnt(world, worker)
+	worker.Health = 0
+
+	processDeaths(world, colony)
+
+	cell := world.GetCell(22, 15)
+	if cell.Occupant != nil {
+		t.Error("Dead worker should be out of the world")
+	}
+	if cell.Corpse == nil || len(world.Corpses) != 1 {
+		t.Fatal("Dead worker should leave a corpse behind")
+	}
+	if cell.Corpse.ColonyID != "Red" || cell.Corpse.Role != types.Worker {
+		t.Error("Corpse should remember who the ant was")
+	}
+}
+
+func TestCorpseBlocksTraffic(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	world.GetCell(10, 10).IsTunnel = true
+	leaveCorpse(world, types.NewAnt(1, types.Worker, 10, 10, "Red", 100, 500))
+
+	if pathfinder.CanMoveTo(world, 10, 10) {
+		t.Error("Ants should not walk over a corpse")
+	}
+}
+
+func TestWorkerCarriesNestmateToMidden(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	world.GetCell(25, 15).IsTunnel = true
+	leaveCorpse(world, types.NewAnt(50, types.Nurse, 25, 15, "Red", 100, 500))
+
+	worker := SpawnWorker(colony, 24, 15)
+	PlaceAnt(world, worker)
+
+	if !collectCorpse(world, colony, worker) {
+		t.Fatal("Worker should deal with the corpse beside it")
+	}
+	if !worker.CarryingCorpse {
+		t.Fatal("Worker should be carrying its dead nestmate")
+	}
+	if len(world.Corpses) != 0 {
+		t.Error("Corpse should have been picked up")
+	}
+
+	// Walk it out to the midden
+	for i := 0; i < 200 && worker.CarryingCorpse; i++ {
+		carryToMidden(world, colony, worker)
+	}
+
+	if worker.CarryingCorpse {
+		t.Fatal("Worker never reached the midden")
+	}
+	if colony.Midden == nil || colony.Midden.Corpses != 1 {
+		t.Error("Midden should hold the body")
+	}
+	if colony.Midden.Center.Y != surfaceRow {
+		t.Errorf("Midden should be on the surface, got row %d", colony.Midden.Center.Y)
+	}
+}
+
+func TestWorkerCarriesRivalCorpseHomeAsProtein(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	world.GetCell(25, 15).IsTunnel = true
+	leaveCorpse(world, types.NewAnt(50, types.Worker, 25, 15, "Blue", 100, 500))
+
+	worker := SpawnWorker(colony, 24, 15)
+	PlaceAnt(world, worker)
+	collectCorpse(world, colony, worker)
+
+	if worker.CarryingCorpse {
+		t.Error("Rival dead should not go to the midden")
+	}
+	if !worker.CarryingFood || worker.FoodType != types.Protein || worker.FoodAmount != corpseProtein {
+		t.Errorf("Expected %d protein, got %d of type %d", corpseProtein, worker.FoodAmount, worker.FoodType)
+	}
+}
+
+func TestFesteringCorpseInfectsNeighbours(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	world.GetCell(22, 15).IsTunnel = true
+	leaveCorpse(world, types.NewAnt(50, types.Worker, 22, 15, "Red", 100, 500))
+	world.Corpses[0].Age = corpseFesterAge
+
+	nurse := colony.HeadNurse // Standing at (21,15)
+	world.Ticks = miasmaInterval
+	updateCorpses(world)
+
+	if nurse.Infection != corpsePathogens {
+		t.Errorf("Expected nurse infection %d, got %d", corpsePathogens, nurse.Infection)
+	}
+}
+
+func TestSurfaceCorpseDriesUp(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	leaveCorpse(world, types.NewAnt(50, types.Worker, 10, surfaceRow, "Red", 100, 500))
+	world.Corpses[0].Age = corpseDryAge - 1
+
+	updateCorpses(world)
+
+	if len(world.Corpses) != 0 || world.GetCell(10, surfaceRow).Corpse != nil {
+		t.Error("Corpse on the surface should have dried up")
+	}
+}
+
+func TestCountCorpsesInside(t *testing.T) {
+	world := &types.World{
+		Corpses: []*types.Corpse{
+			{Position: types.Position{X: 5, Y: 10}, ColonyID: "Red"},
+			{Position: types.Position{X: 6, Y: 1}, ColonyID: "Red"},   // Already outside
+			{Position: types.Position{X: 7, Y: 10}, ColonyID: "Blue"}, // Someone else's dead
+		},
+	}
+	colony := &types.Colony{Name: "Red"}
+
+	if got := CountCorpsesInside(world, colony); got != 1 {
+		t.Errorf("Expected 1 corpse inside, got %d", got)
+	}
+}
@@ -0,0 +1,333 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+)
+
+// critters.go - Aphids and the spiders that hunt them
+// Aphids land on surface plants, crawl slowly along the surface and build up
+// honeydew while they feed. Workers milk them for sugar, and a colony that has
+// milked an aphid herds it: when rival ants or a spider come near, an idle
+// worker or soldier goes to stand over it, chasing off rival milkers and
+// keeping spiders away. Spiders drop in now and then, hunt unguarded aphids,
+// and move on.
+
+// Critter tuning
+var (
+	surfaceRow = 1 // The row of plants critters live on
+
+	aphidArrivalInterval  = 100                 // Ticks between chances of a new aphid landing
+	maxAphids             = 6                   // No more aphids land once this many are about
+	aphidMoveInterval     = 8                   // Aphids crawl one cell this often
+	aphidHoneydewInterval = 5                   // Ticks per unit of honeydew while feeding on a plant
+	aphidHoneydewCapacity = 3 * types.FoodScale // Honeydew an aphid can hold before it stops making more
+	aphidMilkThreshold    = 1 * types.FoodScale // Honeydew worth a worker stopping to milk
+	herdRange             = 10                  // How far a worker will walk to milk or guard a herded aphid
+	herdThreatRange       = 6                   // How close a rival ant or spider has to be to put a herd in danger
+
+	spiderArrivalInterval = 400 // Ticks between chances of a spider turning up
+	spiderArrivalChance   = 50  // Percent chance a spider turns up when it can
+	spiderLifetime        = 200 // Ticks a spider hunts before moving on
+	spiderMoveInterval    = 2   // Spiders move one cell this often
+	spiderBite            = 5   // Damage a spider does to an aphid per bite
+)
+
+// updateCritters advances every critter in the world by one tick
+func updateCritters(world *types.World) {
+	arriveCritters(world)
+
+	for _, critter := range world.Critters {
+		critter.Age++
+		switch critter.Kind {
+		case types.Aphid:
+			updateAphid(world, critter)
+		case types.Spider:
+			updateSpider(world, critter)
+		}
+	}
+
+	// Clear out critters that were killed or have moved on
+	for i := len(world.Critters) - 1; i >= 0; i-- {
+		critter := world.Critters[i]
+		if critter.Kind == types.Spider && critter.IsDead() {
+			leaveCarcass(world, critter)
+		}
+		if critter.IsDead() || (critter.Kind == types.Spider && critter.Age >= spiderLifetime) {
+			RemoveCritter(world, critter)
+		}
+	}
+}
+
+// arriveCritters lands new aphids and spiders on free surface cells
+func arriveCritters(world *types.World) {
+	if world.Ticks%aphidArrivalInterval == 0 && countCritters(world, types.Aphid) < maxAphids {
+		x := int(world.Random.Below(uint32(world.Width)))
+		if isFreeSurface(world, x) {
+			AddCritter(world, types.NewAphid(world.NextCritterID, x, surfaceRow))
+		}
+	}
+
+	if world.Ticks%spiderArrivalInterval == 0 && countCritters(world, types.Spider) == 0 &&
+		world.Random.Chance(uint32(spiderArrivalChance)) {
+		x := int(world.Random.Below(uint32(world.Width)))
+		if isFreeSurface(world, x) {
+			AddCritter(world, types.NewSpider(world.NextCritterID, x, surfaceRow))
+		}
+	}
+}
+
+// updateAphid feeds an aphid on the plant beneath it and crawls it along the surface
+func updateAphid(world *types.World, aphid *types.Critter) {
+	// Harvested cells (Food == -1) have no plant left to feed on
+	cell := world.GetCell(aphid.Position.X, aphid.Position.Y)
+	if cell != nil && cell.Food >= 0 && world.Ticks%aphidHoneydewInterval == 0 &&
+		aphid.Honeydew < aphidHoneydewCapacity {
+		aphid.Honeydew++
+	}
+
+	if world.Ticks%aphidMoveInterval == 0 {
+		step := 1
+		if world.Random.Below(2) == 0 {
+			step = -1
+		}
+		crawlCritter(world, aphid, aphid.Position.X+step)
+	}
+}
+
+// updateSpider bites any ant or unguarded aphid next to it, otherwise stalks
+// the nearest aphid
+func updateSpider(world *types.World, spider *types.Critter) {
+	if biteAnt(world, spider) {
+		return
+	}
+
+	var prey *types.Critter
+	minDist := 9999
+	for _, critter := range world.Critters {
+		if critter.Kind != types.Aphid || critter.IsDead() || isGuarded(world, critter) {
+			continue
+		}
+		dist := pathfinder.ManhattanDistance(spider.Position, critter.Position)
+		if dist < minDist {
+			minDist = dist
+			prey = critter
+		}
+	}
+	if prey == nil {
+		return
+	}
+
+	if pathfinder.IsAdjacent(spider.Position, prey.Position) {
+		prey.Health -= spiderBite
+		return
+	}
+
+	if world.Ticks%spiderMoveInterval == 0 {
+		step := 1
+		if prey.Position.X < spider.Position.X {
+			step = -1
+		}
+		crawlCritter(world, spider, spider.Position.X+step)
+	}
+}
+
+// CountHerd returns how many aphids a colony is herding
+func CountHerd(world *types.World, colony *types.Colony) int {
+	herd := 0
+	for _, critter := range world.Critters {
+		if critter.Kind == types.Aphid && critter.HerdedBy == colony.Name {
+			herd++
+		}
+	}
+	return herd
+}
+
+// isGuarded reports whether an ant of the colony herding this aphid is standing next to it
+func isGuarded(world *types.World, aphid *types.Critter) bool {
+	return herdGuard(world, aphid) != nil
+}
+
+// herdGuard returns an ant of the colony herding this aphid that is standing
+// next to it, or nil if the aphid is wild or unguarded
+func herdGuard(world *types.World, aphid *types.Critter) *types.Ant {
+	if aphid.HerdedBy == "" {
+		return nil
+	}
+	for _, dir := range pathfinder.GetAllDirections() {
+		dx, dy := pathfinder.DirectionToOffset(dir)
+		cell := world.GetCell(aphid.Position.X+dx, aphid.Position.Y+dy)
+		if cell != nil && cell.Occupant != nil && cell.Occupant.GetAnt().ColonyID == aphid.HerdedBy {
+			return cell.Occupant.GetAnt()
+		}
+	}
+	return nil
+}
+
+// herdThreatened reports whether a spider or an ant of another colony is
+// within herdThreatRange of an aphid
+func herdThreatened(world *types.World, aphid *types.Critter) bool {
+	for _, critter := range world.Critters {
+		if critter.Kind == types.Spider && !critter.IsDead() &&
+			pathfinder.ManhattanDistance(critter.Position, aphid.Position) <= herdThreatRange {
+			return true
+		}
+	}
+	for _, colony := range world.Colonies {
+		if colony.Name == aphid.HerdedBy {
+			continue
+		}
+		for _, ant := range colony.GetAllAnts() {
+			if pathfinder.ManhattanDistance(ant.GetAnt().Position, aphid.Position) <= herdThreatRange {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// guardHerd sends an idle worker or soldier to stand over the nearest aphid
+// its colony herds while a rival ant or a spider is about. An ant already
+// beside a threatened aphid stays there. Aphids with a guard of their own are
+// left to them. Returns true if the ant spent its turn guarding
+func guardHerd(world *types.World, colony *types.Colony, ant types.AntInterface) bool {
+	baseAnt := ant.GetAnt()
+
+	var target *types.Critter
+	minDist := herdRange + 1
+	for _, critter := range world.Critters {
+		if critter.Kind != types.Aphid || critter.HerdedBy != colony.Name || critter.IsDead() {
+			continue
+		}
+		dist := pathfinder.ManhattanDistance(baseAnt.Position, critter.Position)
+		if dist >= minDist || !herdThreatened(world, critter) {
+			continue
+		}
+		if pathfinder.IsAdjacent(baseAnt.Position, critter.Position) {
+			baseAnt.CurrentAction = "guarding aphid herd"
+			return true
+		}
+		if isGuarded(world, critter) {
+			continue
+		}
+		minDist = dist
+		target = critter
+	}
+	if target == nil {
+		return false
+	}
+
+	baseAnt.CurrentAction = "going to guard aphid herd"
+	return pathfinder.MoverFor(colony, baseAnt.Role).Toward(world, colony, ant, target.Position)
+}
+
+// milkAphid lets a worker milk an adjacent aphid that has honeydew ready
+// An aphid herded by another colony cannot be milked while one of its guards
+// is beside it. Milking an aphid makes the worker's colony its herder.
+// Returns true if the worker milked an aphid.
+func milkAphid(world *types.World, colony *types.Colony, worker *types.WorkerAnt) bool {
+	for _, critter := range world.Critters {
+		if critter.Kind != types.Aphid || critter.Honeydew < aphidMilkThreshold ||
+			!pathfinder.IsAdjacent(worker.Position, critter.Position) {
+			continue
+		}
+		if critter.HerdedBy != colony.Name {
+			if guard := herdGuard(world, critter); guard != nil {
+				guard.CurrentAction = "chased off a rival milker"
+				worker.CurrentAction = "driven off a guarded aphid"
+				continue
+			}
+		}
+
+		worker.CarryingFood = true
+		worker.FoodAmount = critter.Honeydew
+		worker.FoodType = types.Sugar
+		critter.Honeydew = 0
+		critter.HerdedBy = colony.Name
+		worker.CurrentAction = "milked aphid"
+		return true
+	}
+	return false
+}
+
+// visitHerd walks a worker on the surface toward the nearest aphid its colony
+// herds once that aphid has honeydew ready. Returns true if it set off.
+func visitHerd(world *types.World, colony *types.Colony, worker *types.WorkerAnt) bool {
+	if worker.Position.Y > surfaceRow {
+		return false
+	}
+
+	var target *types.Critter
+	minDist := herdRange + 1
+	for _, critter := range world.Critters {
+		if critter.Kind != types.Aphid || critter.HerdedBy != colony.Name ||
+			critter.Honeydew < aphidMilkThreshold {
+			continue
+		}
+		dist := pathfinder.ManhattanDistance(worker.Position, critter.Position)
+		if dist < minDist {
+			minDist = dist
+			target = critter
+		}
+	}
+	if target == nil {
+		return false
+	}
+
+	worker.CurrentAction = "going to milk aphid"
+	return workerPathfinder.MoveTowardTarget(world, colony, worker, target.Position)
+}
+
+// AddCritter places a critter in the world at its position
+func AddCritter(world *types.World, critter *types.Critter) bool {
+	cell := world.GetCell(critter.Position.X, critter.Position.Y)
+	if cell == nil || cell.Occupant != nil || cell.Critter != nil || cell.Corpse != nil {
+		return false
+	}
+	cell.Critter = critter
+	world.Critters = append(world.Critters, critter)
+	world.NextCritterID++
+	return true
+}
+
+// RemoveCritter takes a critter out of the world
+func RemoveCritter(world *types.World, critter *types.Critter) {
+	cell := world.GetCell(critter.Position.X, critter.Position.Y)
+	if cell != nil && cell.Critter == critter {
+		cell.Critter = nil
+	}
+	for i, c := range world.Critters {
+		if c.ID == critter.ID {
+			world.Critters = append(world.Critters[:i], world.Critters[i+1:]...)
+			return
+		}
+	}
+}
+
+// crawlCritter moves a critter sideways along the surface if the cell is free
+func crawlCritter(world *types.World, critter *types.Critter, newX int) bool {
+	if !isFreeSurface(world, newX) {
+		return false
+	}
+	world.GetCell(critter.Position.X, critter.Position.Y).Critter = nil
+	critter.Position.X = newX
+	world.GetCell(critter.Position.X, critter.Position.Y).Critter = critter
+	return true
+}
+
+// isFreeSurface checks whether a surface cell has nobody in it
+func isFreeSurface(world *types.World, x int) bool {
+	cell := world.GetCell(x, surfaceRow)
+	return cell != nil && cell.Occupant == nil && cell.Critter == nil && cell.Corpse == nil
+}
+
+// countCritters counts the critters of one kind in the world
+func countCritters(world *types.World, kind types.CritterKind) int {
+	count := 0
+	for _, critter := range world.Critters {
+		if critter.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
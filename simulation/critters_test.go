@@ -0,0 +1,188 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestAddAndRemoveCritter(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	aphid := types.NewAphid(world.NextCritterID, 10, 1)
+
+	if !AddCritter(world, aphid) {
+		t.Fatal("Aphid should land on a free surface cell")
+	}
+	if world.GetCell(10, 1).Critter != aphid {
+		t.Error("Aphid should be in its cell")
+	}
+
+	RemoveCritter(world, aphid)
+
+	if world.GetCell(10, 1).Critter != nil || len(world.Critters) != 0 {
+		t.Error("Aphid should be gone from the world")
+	}
+}
+
+func TestCritterBlocksAnts(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	AddCritter(world, types.NewAphid(world.NextCritterID, 10, 1))
+
+	if pathfinder.CanMoveTo(world, 10, 1) {
+		t.Error("Ants should not walk into a cell with an aphid in it")
+	}
+}
+
+func TestAphidMakesHoneydewOnPlants(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	aphid := types.NewAphid(world.NextCritterID, 10, 1)
+	AddCritter(world, aphid)
+	world.GetCell(10, 1).Food = 0 // Standing on grass
+
+	world.Ticks = aphidHoneydewInterval
+	updateAphid(world, aphid)
+
+	if aphid.Honeydew != 1 {
+		t.Errorf("Expected 1 honeydew, got %d", aphid.Honeydew)
+	}
+}
+
+func TestAphidMakesNothingOnHarvestedCell(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	aphid := types.NewAphid(world.NextCritterID, 10, 1)
+	AddCritter(world, aphid)
+	world.GetCell(10, 1).Food = -1
+
+	world.Ticks = aphidHoneydewInterval
+	updateAphid(world, aphid)
+
+	if aphid.Honeydew != 0 {
+		t.Errorf("Aphid on a bare cell should make no honeydew, got %d", aphid.Honeydew)
+	}
+}
+
+func TestWorkerMilksAphid(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+
+	aphid := types.NewAphid(world.NextCritterID, 11, 1)
+	aphid.Honeydew = aphidMilkThreshold
+	AddCritter(world, aphid)
+
+	worker := SpawnWorker(colony, 10, 1)
+	PlaceAnt(world, worker)
+
+	if !milkAphid(world, colony, worker) {
+		t.Fatal("Worker should milk the aphid beside it")
+	}
+	if worker.FoodType != types.Sugar || worker.FoodAmount != aphidMilkThreshold {
+		t.Errorf("Expected %d sugar, got %d of type %d", aphidMilkThreshold, worker.FoodAmount, worker.FoodType)
+	}
+	if aphid.HerdedBy != "Red" {
+		t.Errorf("Milking should make Red the herder, got '%s'", aphid.HerdedBy)
+	}
+}
+
+func TestGuardedAphidCannotBeMilkedByRivals(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	red := types.NewColony("Red", 10, 15, types.ColonyRed)
+	blue := types.NewColony("Blue", 30, 15, types.ColonyBlue)
+	AddColony(world, red)
+	AddColony(world, blue)
+
+	aphid := types.NewAphid(world.NextCritterID, 11, 1)
+	aphid.Honeydew = aphidMilkThreshold
+	aphid.HerdedBy = "Red"
+	AddCritter(world, aphid)
+
+	guard := SpawnWorker(red, 12, 1)
+	PlaceAnt(world, guard)
+	rival := SpawnWorker(blue, 10, 1)
+	PlaceAnt(world, rival)
+
+	if milkAphid(world, blue, rival) {
+		t.Error("Rival should be chased off a guarded aphid")
+	}
+	if aphid.Honeydew != aphidMilkThreshold {
+		t.Error("Guarded aphid should keep its honeydew")
+	}
+}
+
+func TestSpiderBitesUnguardedAphid(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	aphid := types.NewAphid(world.NextCritterID, 11, 1)
+	AddCritter(world, aphid)
+	spider := types.NewSpider(world.NextCritterID, 10, 1)
+	AddCritter(world, spider)
+
+	updateSpider(world, spider)
+
+	if aphid.Health != types.AphidMaxHealth-spiderBite {
+		t.Errorf("Expected aphid health %d, got %d", types.AphidMaxHealth-spiderBite, aphid.Health)
+	}
+}
+
+func TestWorkerGoesToGuardThreatenedHerd(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	red := types.NewColony("Red", 10, 15, types.ColonyRed)
+	blue := types.NewColony("Blue", 30, 15, types.ColonyBlue)
+	AddColony(world, red)
+	AddColony(world, blue)
+
+	aphid := types.NewAphid(world.NextCritterID, 15, 1)
+	aphid.Honeydew = aphidMilkThreshold
+	aphid.HerdedBy = "Red"
+	AddCritter(world, aphid)
+	guard := SpawnWorker(red, 12, 1)
+	PlaceAnt(world, guard)
+
+	if guardHerd(world, red, guard) {
+		t.Fatal("Nobody is about, so the herd needs no guard")
+	}
+
+	rival := SpawnWorker(blue, 19, 1)
+	PlaceAnt(world, rival)
+	for i := 0; i < 5 && !pathfinder.IsAdjacent(guard.Position, aphid.Position); i++ {
+		if !guardHerd(world, red, guard) {
+			t.Fatalf("Worker should head for the threatened herd, at %v", guard.Position)
+		}
+	}
+	if !pathfinder.IsAdjacent(guard.Position, aphid.Position) {
+		t.Fatalf("Worker should be standing over the aphid, at %v", guard.Position)
+	}
+	if !guardHerd(world, red, guard) || guard.Position.X != 14 {
+		t.Errorf("A guard beside a threatened aphid should stay put, at %v", guard.Position)
+	}
+
+	// The rival comes up the other side and is seen off
+	world.GetCell(rival.Position.X, rival.Position.Y).Occupant = nil
+	rival.Position = types.Position{X: 16, Y: 1}
+	PlaceAnt(world, rival)
+	if milkAphid(world, blue, rival) {
+		t.Error("Rival should not milk an aphid with a guard over it")
+	}
+	if guard.CurrentAction != "chased off a rival milker" {
+		t.Errorf("The guard should have chased the rival off, got '%s'", guard.CurrentAction)
+	}
+	if rival.CurrentAction != "driven off a guarded aphid" {
+		t.Errorf("The rival should have been driven off, got '%s'", rival.CurrentAction)
+	}
+}
+
+func TestCountHerd(t *testing.T) {
+	world := &types.World{
+		Critters: []*types.Critter{
+			{Kind: types.Aphid, HerdedBy: "Red"},
+			{Kind: types.Aphid, HerdedBy: "Blue"}, // Someone else's herd
+			{Kind: types.Aphid},                   // Still wild
+			{Kind: types.Spider},
+		},
+	}
+	colony := &types.Colony{Name: "Red"}
+
+	if got := CountHerd(world, colony); got != 1 {
+		t.Errorf("Expected 1 aphid in the herd, got %d", got)
+	}
+}
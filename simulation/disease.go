@@ -0,0 +1,184 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+)
+
+// disease.go - Pathogen load, contagion, grooming and isolation
+// Every ant carries a pathogen load. Festering bodies and contagious
+// neighbours raise it, and an ant's own defences clear a light load on their
+// own. Past that point the infection takes hold: it grows, drains health and
+// slows the ant down. Nurses groom sick nestmates to bring their load down,
+// and badly sick workers and soldiers leave the nest so they stop spreading it.
+// The nearest nurse goes out to groom and feed each of them until its load is
+// low enough for it to come back.
+// There is no dice roll anywhere in here, so the same colony always has the
+// same epidemic.
+
+// Disease tuning, in pathogen load (0 to types.MaxInfection)
+var (
+	diseaseInterval = 10 // Ticks between disease steps
+	contagiousLoad  = 30 // Load at which an ant infects the ants around it
+	contactLoad     = 5  // Load passed to each neighbour per step by a contagious ant
+	immuneThreshold = 20 // Loads below this are fought off by the ant itself
+	immuneClearance = 2  // Load cleared per step while fighting an infection off
+	infectionGrowth = 3  // Load gained per step once an infection has taken hold
+	sicknessDamage  = 1  // Health lost per step once an infection has taken hold
+	sluggishLoad    = 50 // Load at which an ant only manages every other tick
+	groomAmount     = 15 // Load a nurse clears from a nestmate per grooming
+	groomRange      = 6  // How far a nurse will walk to groom a sick nestmate
+	isolateLoad     = 60 // Load at which sick workers and soldiers leave the nest
+	isolateSick     = true
+)
+
+// infect adds pathogens to an ant, up to the maximum load
+func infect(ant *types.Ant, load int) {
+	ant.Infection += load
+	if ant.Infection > types.MaxInfection {
+		ant.Infection = types.MaxInfection
+	}
+}
+
+// updateDisease runs one disease step for every ant in the world
+// Contagious ants are picked out before anyone is infected, so an ant caught
+// this step cannot pass it on until the next one
+func updateDisease(world *types.World) {
+	if world.Ticks%diseaseInterval != 0 {
+		return
+	}
+
+	var contagious []*types.Ant
+	for _, colony := range world.Colonies {
+		for _, ant := range colony.GetAllAnts() {
+			if ant.GetAnt().Infection >= contagiousLoad {
+				contagious = append(contagious, ant.GetAnt())
+			}
+		}
+	}
+
+	// Contact works across colonies: a sick raider infects its enemies too
+	for _, carrier := range contagious {
+		for _, dir := range pathfinder.GetAllDirections() {
+			dx, dy := pathfinder.DirectionToOffset(dir)
+			cell := world.GetCell(carrier.Position.X+dx, carrier.Position.Y+dy)
+			if cell != nil && cell.Occupant != nil {
+				infect(cell.Occupant.GetAnt(), contactLoad)
+			}
+		}
+	}
+
+	for _, colony := range world.Colonies {
+		for _, ant := range colony.GetAllAnts() {
+			progressInfection(ant.GetAnt())
+		}
+	}
+}
+
+// progressInfection lets an ant fight off a light load, or lets a heavy one
+// grow and wear it down
+func progressInfection(ant *types.Ant) {
+	if !ant.IsInfected() {
+		return
+	}
+
+	if ant.Infection < immuneThreshold {
+		ant.Infection -= immuneClearance
+		if ant.Infection < 0 {
+			ant.Infection = 0
+		}
+		return
+	}
+
+	infect(ant, infectionGrowth)
+	ant.Health -= sicknessDamage
+}
+
+// isSluggish reports whether a sick ant is too ill to act this tick
+func isSluggish(world *types.World, ant *types.Ant) bool {
+	return ant.Infection >= sluggishLoad && world.Ticks%2 == 0
+}
+
+// isolating reports whether an ant is sick enough to be kept out of the nest
+// Only workers and soldiers leave; nurses and the queen stay at their posts
+func isolating(ant *types.Ant) bool {
+	return isolateSick && ant.Infection >= isolateLoad &&
+		(ant.Role == types.Worker || ant.Role == types.Soldier)
+}
+
+// isolate sends a badly sick ant out of the nest to wait at the midden, away
+// from its nestmates, until grooming brings its load back down. Returns false
+// if the ant is not sick enough or the colony does not isolate its sick
+func isolate(world *types.World, colony *types.Colony, ant types.AntInterface) bool {
+	baseAnt := ant.GetAnt()
+	if !isolating(baseAnt) {
+		return false
+	}
+
+	midden := middenFor(world, colony)
+	if pathfinder.IsAdjacentOrSame(baseAnt.Position, midden.Center) {
+		baseAnt.CurrentAction = "isolating"
+		return true
+	}
+
+	baseAnt.CurrentAction = "leaving the nest sick"
+	pathfinder.StepToward(world, ant, midden.Center)
+	return true
+}
+
+// groomNestmate has a nurse groom the sickest nestmate within reach, or go
+// out to a nestmate isolating at the midden if she is the nearest nurse to it.
+// Only infections that have taken hold are worth the trip. A hungry patient
+// is fed from the stores while she is there. Returns false if nobody needs
+// grooming
+func groomNestmate(world *types.World, colony *types.Colony, nurse *types.NurseAnt) bool {
+	var patient *types.Ant
+	for _, ant := range colony.GetAllAnts() {
+		other := ant.GetAnt()
+		if other.ID == nurse.ID || other.Infection < immuneThreshold {
+			continue
+		}
+		inReach := pathfinder.ManhattanDistance(nurse.Position, other.Position) <= groomRange
+		if !inReach && !(isolating(other) && nearestNurse(colony, other.Position) == nurse) {
+			continue
+		}
+		if patient == nil || other.Infection > patient.Infection {
+			patient = other
+		}
+	}
+	if patient == nil {
+		return false
+	}
+
+	if !pathfinder.IsAdjacent(nurse.Position, patient.Position) {
+		nurse.CurrentAction = "going to groom a sick nestmate"
+		if !pathfinder.MoverFor(colony, types.Nurse).Toward(world, colony, nurse, patient.Position) {
+			pathfinder.StepToward(world, nurse, patient.Position)
+		}
+		return true
+	}
+
+	patient.Infection -= groomAmount
+	if patient.Infection < 0 {
+		patient.Infection = 0
+	}
+	nurse.CurrentAction = "grooming a sick nestmate"
+	if isHungry(patient) && eat(colony, patient) {
+		nurse.CurrentAction = "grooming and feeding a sick nestmate"
+	}
+	return true
+}
+
+// nearestNurse returns the colony's nurse closest to a position, the head
+// nurse included. Ties go to the first in the list
+func nearestNurse(colony *types.Colony, pos types.Position) *types.NurseAnt {
+	var nearest *types.NurseAnt
+	eachNurse(colony, func(nurse *types.NurseAnt) bool {
+		if nearest == nil ||
+			pathfinder.ManhattanDistance(nurse.Position, pos) < pathfinder.ManhattanDistance(nearest.Position, pos) {
+			nearest = nurse
+		}
+		return true
+	})
+	return nearest
+}
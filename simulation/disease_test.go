@@ -0,0 +1,164 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestContagiousAntInfectsNeighbours(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	colony.HeadNurse.Infection = contagiousLoad // Standing next to the queen
+
+	world.Ticks = diseaseInterval
+	updateDisease(world)
+
+	if !colony.Queen.IsInfected() {
+		t.Error("Queen should have caught it from the nurse beside her")
+	}
+}
+
+func TestLightInfectionIsFoughtOff(t *testing.T) {
+	ant := types.NewAnt(1, types.Worker, 0, 0, "Red", 100, 500)
+	ant.Infection = immuneClearance
+
+	progressInfection(ant)
+
+	if ant.IsInfected() {
+		t.Errorf("Light load should have been cleared, still %d", ant.Infection)
+	}
+	if ant.Health != 100 {
+		t.Error("Fighting off a light load should cost no health")
+	}
+}
+
+func TestInfectionTakesHold(t *testing.T) {
+	ant := types.NewAnt(1, types.Worker, 0, 0, "Red", 100, 500)
+	ant.Infection = immuneThreshold
+
+	progressInfection(ant)
+
+	if ant.Infection != immuneThreshold+infectionGrowth {
+		t.Errorf("Expected load %d, got %d", immuneThreshold+infectionGrowth, ant.Infection)
+	}
+	if ant.Health != 100-sicknessDamage {
+		t.Errorf("Expected health %d, got %d", 100-sicknessDamage, ant.Health)
+	}
+}
+
+func TestSickAntIsSluggish(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	ant := types.NewAnt(1, types.Worker, 0, 0, "Red", 100, 500)
+	ant.Infection = sluggishLoad
+
+	world.Ticks = 2
+	if !isSluggish(world, ant) {
+		t.Error("Sick ant should skip even ticks")
+	}
+	world.Ticks = 3
+	if isSluggish(world, ant) {
+		t.Error("Sick ant should still act on odd ticks")
+	}
+}
+
+func TestNurseGroomsSickNestmate(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	colony.Queen.Infection = 50 // Next to the head nurse
+
+	if !groomNestmate(world, colony, colony.HeadNurse) {
+		t.Fatal("Nurse should groom the sick queen")
+	}
+	if colony.Queen.Infection != 50-groomAmount {
+		t.Errorf("Expected load %d after grooming, got %d", 50-groomAmount, colony.Queen.Infection)
+	}
+}
+
+func TestSickWorkerLeavesNest(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	worker := colony.Workers[0]
+	worker.Infection = isolateLoad
+
+	if !isolate(world, colony, worker) {
+		t.Fatal("Badly sick worker should isolate")
+	}
+	if worker.CurrentAction != "leaving the nest sick" {
+		t.Errorf("Expected worker to be leaving the nest, got '%s'", worker.CurrentAction)
+	}
+
+	worker.Infection = isolateLoad - 1
+	if isolate(world, colony, worker) {
+		t.Error("Worker below the isolation load should keep working")
+	}
+}
+
+func TestDiseaseDeathIsRecorded(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	colony.Workers[0].Infection = types.MaxInfection
+	colony.Workers[0].Health = 0
+
+	processDeaths(world, colony)
+
+	if colony.Deaths[types.Disease] != 1 {
+		t.Errorf("Expected 1 disease death, got %d", colony.Deaths[types.Disease])
+	}
+}
+
+func TestIsolatedAntIsNursedBackIntoTheNest(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	midden := middenFor(world, colony)
+
+	worker := SpawnWorker(colony, midden.Center.X+1, midden.Center.Y)
+	PlaceAnt(world, worker)
+	worker.Infection = isolateLoad + 10
+	worker.Hunger = hungryThreshold
+	if !isolate(world, colony, worker) || worker.CurrentAction != "isolating" {
+		t.Fatalf("Worker should be isolating at the midden, got '%s'", worker.CurrentAction)
+	}
+
+	for i := 0; i < 300 && isolating(worker.Ant); i++ {
+		UpdateWorld(world)
+	}
+
+	if isolating(worker.Ant) {
+		t.Fatalf("A nurse should have groomed the isolated worker back down, load %d", worker.Infection)
+	}
+	if worker.Health <= 0 {
+		t.Error("The isolated worker should have survived")
+	}
+	if isHungry(worker.Ant) {
+		t.Errorf("The isolated worker should have been fed, hunger %d", worker.Hunger)
+	}
+	if isolate(world, colony, worker) {
+		t.Error("A recovered worker should come back to work")
+	}
+}
+
+func TestOnlyNearestNurseVisitsIsolatedAnt(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	far := SpawnNurse(colony, 20, 25)
+	world.GetCell(20, 25).IsTunnel = true
+	PlaceAnt(world, far)
+
+	worker := SpawnWorker(colony, 5, surfaceRow)
+	PlaceAnt(world, worker)
+	worker.Infection = isolateLoad
+
+	if groomNestmate(world, colony, far) {
+		t.Error("Only the nearest nurse should go out to an isolated ant")
+	}
+	if !groomNestmate(world, colony, colony.HeadNurse) {
+		t.Error("The nearest nurse should go out to the isolated ant")
+	}
+}
@@ -0,0 +1,88 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+)
+
+// foodmemory.go - Workers going back to where they last found food
+// A worker that picks up food and sees more left nearby remembers the spot.
+// Once it has delivered its load it heads straight back there rather than
+// wandering off at random. Memories fade if the worker does not go back, and
+// a site it finds picked clean is forgotten on the spot.
+
+// Food memory tuning
+var (
+	foodMemorySpan = 600 // Ticks a worker remembers a food site it has not been back to
+	foodSiteRadius = 3   // Cells around a site a worker looks for food left behind
+)
+
+// hasFood reports whether a cell has food a worker could pick up: a pellet,
+// or surface grass nobody has cut yet
+func hasFood(world *types.World, x, y int) bool {
+	cell := world.GetCell(x, y)
+	if cell == nil {
+		return false
+	}
+	if cell.Food > 0 {
+		return true
+	}
+	return y == surfaceRow && cell.Soil == types.Empty && cell.Food >= 0
+}
+
+// foodNear finds the nearest food within foodSiteRadius of a position
+// Returns false if the area has been picked clean
+func foodNear(world *types.World, pos types.Position) (types.Position, bool) {
+	best := types.Position{}
+	bestDist := -1
+	for dy := -foodSiteRadius; dy <= foodSiteRadius; dy++ {
+		for dx := -foodSiteRadius; dx <= foodSiteRadius; dx++ {
+			x, y := pos.X+dx, pos.Y+dy
+			if !hasFood(world, x, y) {
+				continue
+			}
+			dist := max(dx, -dx, dy, -dy)
+			if bestDist < 0 || dist < bestDist {
+				best = types.Position{X: x, Y: y}
+				bestDist = dist
+			}
+		}
+	}
+	return best, bestDist >= 0
+}
+
+// noteFoodSite updates a worker's memory after it picks up food
+// The site is remembered if there is food left around it, forgotten if not
+func noteFoodSite(world *types.World, worker *types.WorkerAnt) {
+	if _, ok := foodNear(world, worker.Position); ok {
+		worker.RememberFood(worker.Position, foodMemorySpan)
+	} else {
+		worker.ForgetFood(worker.Position)
+	}
+}
+
+// returnToFoodSite walks an unladen worker back to the freshest food site it
+// remembers. At the site it moves on to the nearest food left there, or
+// forgets the site if there is none. Returns true if the worker moved.
+func returnToFoodSite(world *types.World, colony *types.Colony, worker *types.WorkerAnt) bool {
+	if len(worker.FoodSites) == 0 {
+		return false
+	}
+	site := worker.FoodSites[0]
+
+	goal := site.Position
+	if pathfinder.IsAdjacentOrSame(worker.Position, site.Position) {
+		food, ok := foodNear(world, site.Position)
+		if !ok {
+			worker.ForgetFood(site.Position)
+			return false
+		}
+		goal = food
+	}
+
+	if !pathfinder.MoverFor(colony, types.Worker).Toward(world, colony, worker, goal) {
+		return false
+	}
+	worker.CurrentAction = "returning to food"
+	return true
+}
@@ -0,0 +1,63 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+	"testing"
+)
+
+func TestPickingUpFoodRemembersSiteWithFoodLeft(t *testing.T) {
+	world := scentWorld()
+	colony := types.NewColony("Red", 30, 20, types.ColonyRed)
+	world.GetCell(10, 10).Food = 5 * types.FoodScale
+	world.GetCell(12, 10).Food = 5 * types.FoodScale
+	worker := types.NewWorker(1, 10, 10, "Red")
+	world.GetCell(10, 10).Occupant = worker
+
+	workerBehavior(world, colony, worker)
+	if len(worker.FoodSites) != 1 || worker.FoodSites[0].Position != (types.Position{X: 10, Y: 10}) {
+		t.Fatalf("Worker should remember where it found food with more beside it, got %v", worker.FoodSites)
+	}
+
+	// The last pellet leaves nothing to come back for
+	pathfinder.Move(world, worker, 12, 10)
+	worker.CarryingFood = false
+	workerBehavior(world, colony, worker)
+	for _, site := range worker.FoodSites {
+		if site.Position == (types.Position{X: 12, Y: 10}) {
+			t.Error("A site picked clean should not be remembered")
+		}
+	}
+}
+
+func TestWorkerReturnsToRememberedFood(t *testing.T) {
+	world := scentWorld()
+	colony := types.NewColony("Red", 5, 10, types.ColonyRed)
+	world.GetCell(25, 10).Food = 5 * types.FoodScale
+	worker := types.NewWorker(1, 6, 10, "Red")
+	world.GetCell(6, 10).Occupant = worker
+	worker.RememberFood(types.Position{X: 25, Y: 10}, foodMemorySpan)
+
+	for i := 0; i < 25 && !worker.CarryingFood; i++ {
+		workerBehavior(world, colony, worker)
+	}
+
+	if !worker.CarryingFood {
+		t.Errorf("Worker should have gone back and picked up the food, stopped at %v", worker.Position)
+	}
+}
+
+func TestWorkerForgetsEmptySite(t *testing.T) {
+	world := scentWorld()
+	colony := types.NewColony("Red", 5, 10, types.ColonyRed)
+	worker := types.NewWorker(1, 20, 10, "Red")
+	world.GetCell(20, 10).Occupant = worker
+	worker.RememberFood(types.Position{X: 21, Y: 10}, foodMemorySpan)
+
+	if returnToFoodSite(world, colony, worker) {
+		t.Error("Worker should not set off for a site with nothing left")
+	}
+	if len(worker.FoodSites) != 0 {
+		t.Errorf("Empty site should be forgotten, got %v", worker.FoodSites)
+	}
+}
@@ -0,0 +1,117 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+)
+
+// fungus.go - Fungus garden founding, growth and tending
+// Workers bring cut grass down to the garden instead of the stores. While the
+// garden is tended it breaks the grass down into more food than it was worth
+// raw, giving the colony a food source that does not depend on the surface.
+
+// Fungus garden tuning
+var (
+	fungusGrowthInterval = 2   // Ticks to break down one unit of vegetation
+	fungusYield          = 3   // Food units grown per unit of vegetation; grass eaten raw is worth 1
+	gardenTendInterval   = 50  // Ticks after tending before the garden wants care again
+	gardenNeglectLimit   = 300 // Ticks without tending before the garden dies
+)
+
+// gardenOffsets are the spots around the queen, nearest first, where a colony
+// tries to dig its fungus garden
+var gardenOffsets = [][2]int{
+	{0, 3}, {3, 2}, {-3, 2}, {4, 0}, {-4, 0}, {0, 5},
+}
+
+// foundGarden picks a dry underground spot near the queen for a new garden
+// Returns nil if there is nowhere suitable
+func foundGarden(world *types.World, colony *types.Colony) *types.FungusGarden {
+	for _, offset := range gardenOffsets {
+		x := colony.QueenPosition.X + offset[0]
+		y := colony.QueenPosition.Y + offset[1]
+
+		cell := world.GetCell(x, y)
+		if cell == nil || cell.Soil == types.Empty || cell.Soil == types.Rock || cell.IsFlooded() {
+			continue
+		}
+
+		colony.Garden = types.NewFungusGarden(x, y, world.Ticks)
+		return colony.Garden
+	}
+	return nil
+}
+
+// updateGarden grows the colony's fungus and kills the garden if it has been
+// neglected or flooded. A dead garden loses everything in it.
+func updateGarden(world *types.World, colony *types.Colony) {
+	garden := colony.Garden
+	if garden == nil {
+		return
+	}
+
+	cell := world.GetCell(garden.Position.X, garden.Position.Y)
+	if cell == nil || cell.IsFlooded() || world.Ticks-garden.LastTended > gardenNeglectLimit {
+		colony.Garden = nil
+		return
+	}
+
+	// A tender who died on the way frees the job for someone else
+	if garden.TenderID != -1 && !hasAnt(colony, garden.TenderID) {
+		garden.TenderID = -1
+	}
+
+	if garden.Vegetation > 0 && world.Ticks%fungusGrowthInterval == 0 {
+		garden.Vegetation--
+		garden.Grown += fungusYield
+		colony.Food[types.Carbohydrate] += fungusYield
+	}
+}
+
+// gardenForGrass returns the garden a worker should take cut grass to,
+// founding one if the colony has none. Returns nil if no garden can be dug.
+func gardenForGrass(world *types.World, colony *types.Colony) *types.FungusGarden {
+	if colony.Garden != nil {
+		return colony.Garden
+	}
+	return foundGarden(world, colony)
+}
+
+// tendGarden sends an idle ant to look after the garden once it wants care
+// Only one ant takes the job at a time. Returns true if the ant spent its turn
+// on the garden.
+func tendGarden(world *types.World, colony *types.Colony, ant types.AntInterface) bool {
+	garden := colony.Garden
+	if garden == nil {
+		return false
+	}
+
+	baseAnt := ant.GetAnt()
+	if garden.TenderID == -1 && world.Ticks-garden.LastTended >= gardenTendInterval {
+		garden.TenderID = baseAnt.ID
+	}
+	if garden.TenderID != baseAnt.ID {
+		return false
+	}
+
+	if pathfinder.IsAdjacentOrSame(baseAnt.Position, garden.Position) {
+		garden.LastTended = world.Ticks
+		garden.TenderID = -1
+		baseAnt.CurrentAction = "tending fungus garden"
+		return true
+	}
+
+	baseAnt.CurrentAction = "going to fungus garden"
+	pathfinder.StepToward(world, ant, garden.Position)
+	return true
+}
+
+// hasAnt checks whether an ant with the given ID is still part of the colony
+func hasAnt(colony *types.Colony, id int) bool {
+	for _, ant := range colony.GetAllAnts() {
+		if ant.GetAnt().ID == id {
+			return true
+		}
+	}
+	return false
+}
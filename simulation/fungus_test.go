@@ -0,0 +1,131 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestFoundGardenBelowQueen(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+
+	garden := foundGarden(world, colony)
+
+	if garden == nil {
+		t.Fatal("Expected a garden to be founded")
+	}
+	if colony.Garden != garden {
+		t.Error("Garden should be stored on the colony")
+	}
+	if garden.Position.X != 20 || garden.Position.Y != 18 {
+		t.Errorf("Expected garden at (20,18), got (%d,%d)", garden.Position.X, garden.Position.Y)
+	}
+}
+
+func TestFoundGardenAvoidsFloodedSoil(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	world.GetCell(20, 18).Moisture = types.FloodedMoisture
+
+	garden := foundGarden(world, colony)
+
+	if garden == nil {
+		t.Fatal("Expected a garden to be founded")
+	}
+	if garden.Position.X == 20 && garden.Position.Y == 18 {
+		t.Error("Garden should not be dug in flooded soil")
+	}
+}
+
+func TestGardenGrowsFood(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	garden := foundGarden(world, colony)
+	garden.Vegetation = 5
+
+	initialCarbs := colony.Food[types.Carbohydrate]
+	world.Ticks = fungusGrowthInterval
+	updateGarden(world, colony)
+
+	if garden.Vegetation != 4 {
+		t.Errorf("Expected 4 vegetation left, got %d", garden.Vegetation)
+	}
+	if colony.Food[types.Carbohydrate] != initialCarbs+fungusYield {
+		t.Errorf("Expected %d carbohydrate grown, got %d", fungusYield, colony.Food[types.Carbohydrate]-initialCarbs)
+	}
+}
+
+func TestNeglectedGardenDies(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	foundGarden(world, colony)
+
+	world.Ticks = gardenNeglectLimit + 1
+	updateGarden(world, colony)
+
+	if colony.Garden != nil {
+		t.Error("Neglected garden should die")
+	}
+}
+
+func TestFloodedGardenDies(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	garden := foundGarden(world, colony)
+
+	world.GetCell(garden.Position.X, garden.Position.Y).Moisture = 100
+	updateGarden(world, colony)
+
+	if colony.Garden != nil {
+		t.Error("Flooded garden should die")
+	}
+}
+
+func TestIdleWorkerTendsGarden(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	garden := foundGarden(world, colony)
+
+	worker := SpawnWorker(colony, garden.Position.X, garden.Position.Y-1)
+	world.GetCell(worker.Position.X, worker.Position.Y).IsTunnel = true
+	PlaceAnt(world, worker)
+
+	world.Ticks = gardenTendInterval
+	if !tendGarden(world, colony, worker) {
+		t.Fatal("Idle worker should tend a garden that needs care")
+	}
+	if garden.LastTended != world.Ticks {
+		t.Errorf("Expected garden tended at tick %d, got %d", world.Ticks, garden.LastTended)
+	}
+}
+
+func TestWorkerBringsGrassToGarden(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	garden := foundGarden(world, colony)
+
+	worker := SpawnWorker(colony, garden.Position.X+1, garden.Position.Y)
+	world.GetCell(worker.Position.X, worker.Position.Y).IsTunnel = true
+	PlaceAnt(world, worker)
+	worker.CarryingFood = true
+	worker.CarryingGrass = true
+	worker.FoodAmount = 50
+
+	initialFood := colony.Food.Total()
+	UpdateWorld(world)
+
+	if garden.Vegetation != 50 {
+		t.Errorf("Expected 50 vegetation in the garden, got %d", garden.Vegetation)
+	}
+	if colony.Food.Total() != initialFood {
+		t.Error("Grass should go to the garden, not the stores")
+	}
+}
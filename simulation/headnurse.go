@@ -0,0 +1,109 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+	"sort"
+)
+
+// headnurse.go - The head nurse and who looks after which larvae
+// The head nurse runs the nursery. Each tick she hands every larvae nobody is
+// caring for to the nearest nurse with nothing to do, hungriest larvae first,
+// so nurses never have to work out between themselves who takes what. If she
+// dies, the nurse who has raised the most larvae takes over, the oldest if
+// two have raised as many. A colony without nurses has no head nurse until
+// one matures, and until then its nurses pick their own larvae.
+
+// promoteHeadNurse makes the most experienced nurse head nurse if the colony
+// has none. Returns the nurse promoted, or nil
+func promoteHeadNurse(world *types.World, colony *types.Colony) *types.NurseAnt {
+	if colony.HeadNurse != nil || len(colony.Nurses) == 0 {
+		return nil
+	}
+
+	best := colony.Nurses[0]
+	for _, nurse := range colony.Nurses[1:] {
+		if nurse.LarvaeNursed > best.LarvaeNursed ||
+			(nurse.LarvaeNursed == best.LarvaeNursed && nurse.Age > best.Age) {
+			best = nurse
+		}
+	}
+
+	RemoveNurse(colony, best)
+	colony.HeadNurse = best
+	best.CurrentAction = "promoted to head nurse"
+	world.ReportEvent("%s became head nurse", describe(best.Ant))
+	return best
+}
+
+// coordinateNursing has the head nurse give every larvae without care to the
+// nearest idle nurse, hungriest larvae first. Does nothing without a head nurse
+func coordinateNursing(colony *types.Colony) {
+	if colony.HeadNurse == nil {
+		return
+	}
+
+	var idle []*types.NurseAnt
+	eachNurse(colony, func(nurse *types.NurseAnt) bool {
+		// Let go of larvae that have matured or died
+		if nurse.CurrentlyNursing != nil && !hasLarvae(colony, nurse.CurrentlyNursing) {
+			nurse.CurrentlyNursing = nil
+		}
+		if nurse.CurrentlyNursing == nil && nurse.Health > 0 {
+			idle = append(idle, nurse)
+		}
+		return true
+	})
+
+	var waiting []*types.LarvaeAnt
+	for _, larvae := range colony.Larvae {
+		if !larvae.HasNurseCare {
+			waiting = append(waiting, larvae)
+		}
+	}
+	sort.SliceStable(waiting, func(i, j int) bool {
+		return waiting[i].Hunger > waiting[j].Hunger
+	})
+
+	for _, larvae := range waiting {
+		if len(idle) == 0 {
+			return
+		}
+		nearest := 0
+		for i, nurse := range idle {
+			if pathfinder.ManhattanDistance(nurse.Position, larvae.Position) <
+				pathfinder.ManhattanDistance(idle[nearest].Position, larvae.Position) {
+				nearest = i
+			}
+		}
+		idle[nearest].CurrentlyNursing = larvae
+		larvae.HasNurseCare = true
+		idle = append(idle[:nearest], idle[nearest+1:]...)
+	}
+}
+
+// eachNurse calls fn for every nurse in the colony, the head nurse first,
+// and stops early if fn returns false. It walks the head nurse and then the
+// colony's list in place, so it costs nothing however often it is called
+func eachNurse(colony *types.Colony, fn func(nurse *types.NurseAnt) bool) {
+	if colony.HeadNurse != nil && !fn(colony.HeadNurse) {
+		return
+	}
+	for _, nurse := range colony.Nurses {
+		if !fn(nurse) {
+			return
+		}
+	}
+}
+
+// nurseOf returns the nurse in charge of a larvae, or nil if nobody is
+func nurseOf(colony *types.Colony, larvae *types.LarvaeAnt) *types.NurseAnt {
+	var found *types.NurseAnt
+	eachNurse(colony, func(nurse *types.NurseAnt) bool {
+		if nurse.CurrentlyNursing != nil && nurse.CurrentlyNursing.ID == larvae.ID {
+			found = nurse
+		}
+		return found == nil
+	})
+	return found
+}
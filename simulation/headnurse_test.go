@@ -0,0 +1,126 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"strings"
+	"testing"
+)
+
+func TestPromoteMostExperiencedNurse(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	colony.HeadNurse = nil
+	novice := SpawnNurse(colony, 10, 5)
+	veteran := SpawnNurse(colony, 11, 5)
+	elder := SpawnNurse(colony, 12, 5)
+	novice.Age = 900
+	veteran.LarvaeNursed = 3
+	elder.LarvaeNursed = 3
+	elder.Age = 50
+
+	if got := promoteHeadNurse(world, colony); got != elder {
+		t.Fatalf("Expected the older of the two most experienced nurses to be promoted, got %v", got)
+	}
+	if colony.HeadNurse != elder || len(colony.Nurses) != 2 {
+		t.Errorf("Head nurse should leave the nurse list, got %d nurses", len(colony.Nurses))
+	}
+	if len(world.Events) != 1 || !strings.Contains(world.Events[0].Text, "became head nurse") {
+		t.Errorf("Promotion should be reported, got %v", world.Events)
+	}
+	if promoteHeadNurse(world, colony) != nil {
+		t.Error("A colony with a head nurse should promote nobody")
+	}
+}
+
+func TestHeadNurseSucceededOnDeath(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	nurse := SpawnNurse(colony, 25, 15)
+	world.GetCell(25, 15).IsTunnel = true
+	PlaceAnt(world, nurse)
+
+	colony.HeadNurse.Health = 0
+	processDeaths(world, colony)
+
+	if colony.HeadNurse != nurse {
+		t.Fatal("The surviving nurse should take over as head nurse")
+	}
+	if len(colony.Nurses) != 0 {
+		t.Errorf("Expected no other nurses, got %d", len(colony.Nurses))
+	}
+}
+
+func TestNoSuccessorWithoutNurses(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+
+	colony.HeadNurse.Health = 0
+	processDeaths(world, colony)
+
+	if colony.HeadNurse != nil || len(world.Events) != 0 {
+		t.Error("A colony with no nurses left has nobody to promote")
+	}
+}
+
+func TestHeadNurseAssignsHungriestLarvaeToNearestNurse(t *testing.T) {
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	colony.HeadNurse.CurrentlyNursing = SpawnLarvae(colony, 21, 16) // Busy already
+	colony.HeadNurse.CurrentlyNursing.HasNurseCare = true
+	near := SpawnNurse(colony, 10, 5)
+	far := SpawnNurse(colony, 30, 5)
+	content := SpawnLarvae(colony, 28, 5)
+	hungry := SpawnLarvae(colony, 29, 5)
+	hungry.Hunger = 100
+
+	coordinateNursing(colony)
+
+	if far.CurrentlyNursing != hungry {
+		t.Errorf("Hungriest larvae should go to the nurse nearest it")
+	}
+	if near.CurrentlyNursing != content {
+		t.Errorf("Next larvae should go to the nurse left over")
+	}
+	if !hungry.HasNurseCare || !content.HasNurseCare {
+		t.Error("Assigned larvae should be marked as cared for")
+	}
+}
+
+func TestNursesWaitForHeadNurse(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	nurse := SpawnNurse(colony, 21, 16)
+	larvae := SpawnLarvae(colony, 22, 16)
+
+	nurseBehavior(world, colony, nurse)
+	if nurse.CurrentlyNursing != nil || larvae.HasNurseCare {
+		t.Error("Under a head nurse, nurses should not pick their own larvae")
+	}
+
+	colony.HeadNurse = nil
+	nurseBehavior(world, colony, nurse)
+	if nurse.CurrentlyNursing != larvae {
+		t.Error("Without a head nurse, a nurse should pick the nearest larvae herself")
+	}
+}
+
+func TestNurseOfFindsHeadNurseFirstWithoutAllocating(t *testing.T) {
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	nurse := SpawnNurse(colony, 21, 16)
+	larvae := SpawnLarvae(colony, 22, 16)
+	nurse.CurrentlyNursing = larvae
+
+	if got := nurseOf(colony, larvae); got != nurse {
+		t.Fatal("Expected the nurse in charge of the larvae")
+	}
+	colony.HeadNurse.CurrentlyNursing = larvae
+	if got := nurseOf(colony, larvae); got != colony.HeadNurse {
+		t.Error("The head nurse should be looked at before the other nurses")
+	}
+	if allocs := testing.AllocsPerRun(10, func() { nurseOf(colony, larvae) }); allocs != 0 {
+		t.Errorf("Looking through the nurses should not allocate, got %v allocations", allocs)
+	}
+}
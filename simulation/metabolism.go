@@ -0,0 +1,112 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+)
+
+// metabolism.go - Adult hunger, meals and starvation
+// Every adult burns food at its role's rate. Hungry ants head back to the
+// colony stores to eat, and an ant that goes without for too long starts
+// losing health until it starves.
+
+// Metabolism tuning, in hunger points. Ants build up hunger at their role's
+// metabolism rate every tick (see types/ant.go).
+var (
+	hungerPerFoodUnit  = 100 // Hunger satisfied by one food unit (0.1 food)
+	hungryThreshold    = 300 // Hunger at which an ant goes back to eat
+	starvingThreshold  = 600 // Hunger at which an ant starts losing health
+	starvationInterval = 2   // Ticks per health point lost while starving
+
+	// Larvae have no reserves, so they start starving much sooner than adults
+	larvaeStarvingThreshold = 100
+)
+
+// metabolismRate returns how much hunger an ant of the given role builds up per tick
+func metabolismRate(role types.Role) int {
+	switch role {
+	case types.Worker:
+		return types.WorkerMetabolism
+	case types.Soldier:
+		return types.SoldierMetabolism
+	case types.Nurse:
+		return types.NurseMetabolism
+	case types.Queen:
+		return types.QueenMetabolism
+	case types.Larvae:
+		return types.LarvaeMetabolism
+	default:
+		return 0
+	}
+}
+
+// starvationPoint returns the hunger at which an ant of the given role starts starving
+func starvationPoint(role types.Role) int {
+	if role == types.Larvae {
+		return larvaeStarvingThreshold
+	}
+	return starvingThreshold
+}
+
+// metabolize builds up one tick of hunger and drains health once the ant is starving
+func metabolize(world *types.World, ant *types.Ant) {
+	ant.Hunger += metabolismRate(ant.Role)
+	ant.Starving = ant.Hunger >= starvationPoint(ant.Role)
+	if ant.Starving && world.Ticks%starvationInterval == 0 {
+		ant.Health--
+	}
+}
+
+// isHungry reports whether the ant wants a meal
+func isHungry(ant *types.Ant) bool {
+	return ant.Hunger >= hungryThreshold
+}
+
+// eat feeds an adult from the colony stores until it is full or the stores run out
+// Meals come out of the adult diet. Returns false if there was nothing to eat
+func eat(colony *types.Colony, ant *types.Ant) bool {
+	// Round up so a meal always clears the hunger it pays for
+	units := (ant.Hunger + hungerPerFoodUnit - 1) / hungerPerFoodUnit
+	units = colony.Food.Spend(units, adultDiet...)
+	if units <= 0 {
+		return false
+	}
+
+	ant.Hunger -= units * hungerPerFoodUnit
+	if ant.Hunger < 0 {
+		ant.Hunger = 0
+	}
+	ant.Starving = ant.Hunger >= starvationPoint(ant.Role)
+	return true
+}
+
+// seekMeal spends a hungry ant's turn eating at the stores or walking back to them
+// Returns false if the ant is not hungry or the stores are empty, in which case
+// it should get on with its job instead
+func seekMeal(world *types.World, colony *types.Colony, ant types.AntInterface) bool {
+	baseAnt := ant.GetAnt()
+	if !isHungry(baseAnt) || colony.Food.Available(adultDiet...) <= 0 {
+		return false
+	}
+
+	store := colony.StorePosition()
+	if pathfinder.IsAdjacentOrSame(baseAnt.Position, store) {
+		eat(colony, baseAnt)
+		baseAnt.CurrentAction = "eating"
+		return true
+	}
+
+	baseAnt.CurrentAction = "going to eat"
+	pathfinder.StepToward(world, ant, store)
+	return true
+}
+
+// feedQueen builds up the queen's hunger and lets her eat in place
+// She lives on top of the stores, so she never has to walk for a meal
+func feedQueen(world *types.World, colony *types.Colony) {
+	queen := colony.Queen
+	metabolize(world, queen.Ant)
+	if isHungry(queen.Ant) && eat(colony, queen.Ant) {
+		queen.CurrentAction = "eating"
+	}
+}
@@ -0,0 +1,111 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestMetabolizeBuildsHunger(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	soldier := types.NewSoldier(1, 5, 5, "Red")
+	worker := types.NewWorker(2, 6, 5, "Red")
+
+	metabolize(world, soldier.Ant)
+	metabolize(world, worker.Ant)
+
+	if soldier.Hunger != types.SoldierMetabolism {
+		t.Errorf("Expected soldier hunger %d, got %d", types.SoldierMetabolism, soldier.Hunger)
+	}
+	if worker.Hunger != types.WorkerMetabolism {
+		t.Errorf("Expected worker hunger %d, got %d", types.WorkerMetabolism, worker.Hunger)
+	}
+}
+
+func TestStarvingAntLosesHealth(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	worker := types.NewWorker(1, 5, 5, "Red")
+	worker.Hunger = starvingThreshold
+
+	for i := 0; i < starvationInterval; i++ {
+		world.Ticks++
+		metabolize(world, worker.Ant)
+	}
+
+	if !worker.Starving {
+		t.Error("Worker should be starving")
+	}
+	if worker.Health != 99 {
+		t.Errorf("Expected health 99, got %d", worker.Health)
+	}
+}
+
+func TestEatPaysFromStores(t *testing.T) {
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	colony.Food = types.FoodStore{types.Carbohydrate: 10}
+	worker := types.NewWorker(1, 21, 15, "Red")
+	worker.Hunger = 2*hungerPerFoodUnit + 1
+
+	if !eat(colony, worker.Ant) {
+		t.Fatal("Worker should have eaten")
+	}
+	if colony.Food[types.Carbohydrate] != 7 {
+		t.Errorf("Expected 3 units eaten leaving 7, got %d", colony.Food[types.Carbohydrate])
+	}
+	if worker.Hunger != 0 {
+		t.Errorf("Expected hunger 0 after eating, got %d", worker.Hunger)
+	}
+}
+
+func TestEatWithEmptyStores(t *testing.T) {
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	colony.Food = types.FoodStore{}
+	worker := types.NewWorker(1, 21, 15, "Red")
+	worker.Hunger = starvingThreshold
+
+	if eat(colony, worker.Ant) {
+		t.Error("Worker should not eat from empty stores")
+	}
+	if worker.Hunger != starvingThreshold {
+		t.Errorf("Hunger should be unchanged, got %d", worker.Hunger)
+	}
+}
+
+func TestHungryWorkerWalksHome(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+
+	worker := SpawnWorker(colony, 25, 15)
+	world.GetCell(25, 15).IsTunnel = true
+	PlaceAnt(world, worker)
+	worker.Hunger = hungryThreshold
+
+	UpdateWorld(world)
+
+	if worker.Position.X != 24 {
+		t.Errorf("Hungry worker should step toward the stores, at (%d,%d)", worker.Position.X, worker.Position.Y)
+	}
+	if worker.CurrentAction != "going to eat" {
+		t.Errorf("Expected 'going to eat', got '%s'", worker.CurrentAction)
+	}
+}
+
+func TestStarvationDeathIsRecorded(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+
+	worker := colony.Workers[0]
+	worker.Health = 0
+	worker.Starving = true
+
+	UpdateWorld(world)
+
+	if colony.Deaths[types.Starvation] != 1 {
+		t.Errorf("Expected 1 starvation death, got %d", colony.Deaths[types.Starvation])
+	}
+	if colony.Deaths[types.OldAge] != 0 {
+		t.Errorf("Starvation should not count as old age, got %d", colony.Deaths[types.OldAge])
+	}
+}
@@ -0,0 +1,116 @@
+package logic
+
+import (
+	"antfarm/types"
+)
+
+// moisture.go - Rain soaking into the nest
+// Every so often it rains. Water runs in at the nest entrances and down the
+// tunnels, spreads along their floors where it cannot go lower and soaks
+// into the soil either side. Low chambers collect it, so a garden or store
+// sited under a deep shaft can end up waterlogged. Between rains the ground
+// slowly dries back to its natural dampness.
+
+// Moisture tuning
+var (
+	rainInterval = 1000 // Ticks between the start of one rain and the next
+	rainDuration = 100  // Ticks each rain lasts
+	seepInterval = 5    // Ticks between water moving through the ground
+	rainSoak     = 10   // Moisture added to each open entrance per seep while it rains
+	drainRate    = 20   // Most moisture that runs down into the tunnel below per seep
+	soakLoss     = 5    // Soil next to a tunnel soaks up to this much less than the tunnel holds
+	soakRate     = 5    // Most moisture soil soaks up per seep
+	dryRate      = 1    // Moisture lost per seep by a cell wetter than its natural dampness
+)
+
+// raining reports whether it is raining this tick
+func raining(world *types.World) bool {
+	return world.Ticks >= rainInterval && world.Ticks%rainInterval < rainDuration
+}
+
+// updateMoisture lets the rain in and moves water through the ground
+// Runs every seepInterval ticks. Water only moves through open tunnels, and
+// soil only takes up water from a tunnel beside it
+func updateMoisture(world *types.World) {
+	if world.Ticks%seepInterval != 0 {
+		return
+	}
+
+	dry(world)
+	if raining(world) {
+		for x := 0; x < world.Width; x++ {
+			if cell := world.GetCell(x, surfaceRow+1); cell != nil && cell.IsTunnel {
+				cell.Moisture = min(100, cell.Moisture+rainSoak)
+			}
+		}
+	}
+
+	// Bottom up, so water moves one cell a seep rather than straight to the
+	// bottom of a shaft
+	for y := world.Height - 1; y > surfaceRow; y-- {
+		for x := 0; x < world.Width; x++ {
+			cell := world.GetCell(x, y)
+			if cell.IsTunnel && cell.Moisture > world.BaseMoisture(y) {
+				seep(world, x, y)
+			}
+		}
+	}
+
+	for y := world.Height - 1; y > surfaceRow; y-- {
+		for x := 0; x < world.Width; x++ {
+			if cell := world.GetCell(x, y); !cell.IsTunnel && cell.Soil != types.Rock {
+				soak(world, x, y)
+			}
+		}
+	}
+}
+
+// dry takes every cell wetter than its natural dampness a little way back
+func dry(world *types.World) {
+	for y := surfaceRow + 1; y < world.Height; y++ {
+		base := world.BaseMoisture(y)
+		for x := 0; x < world.Width; x++ {
+			if cell := world.GetCell(x, y); cell.Moisture > base {
+				cell.Moisture = max(base, cell.Moisture-dryRate)
+			}
+		}
+	}
+}
+
+// seep moves the water in an open tunnel cell on: down into the tunnel below
+// if there is one, otherwise out along the floor into drier tunnel either side
+func seep(world *types.World, x, y int) {
+	cell := world.GetCell(x, y)
+	excess := cell.Moisture - world.BaseMoisture(y)
+
+	if below := world.GetCell(x, y+1); below != nil && below.IsTunnel && below.Moisture < 100 {
+		flow := min(excess, drainRate, 100-below.Moisture)
+		cell.Moisture -= flow
+		below.Moisture += flow
+		return
+	}
+
+	for _, dx := range []int{-1, 1} {
+		side := world.GetCell(x+dx, y)
+		if side == nil || !side.IsTunnel || side.Moisture >= cell.Moisture {
+			continue
+		}
+		flow := (cell.Moisture - side.Moisture) / 3
+		cell.Moisture -= flow
+		side.Moisture += flow
+	}
+}
+
+// soak has a soil cell take up water from the wettest open tunnel beside it
+func soak(world *types.World, x, y int) {
+	cell := world.GetCell(x, y)
+	wettest := 0
+	for _, offset := range [][2]int{{0, -1}, {-1, 0}, {1, 0}, {0, 1}} {
+		if side := world.GetCell(x+offset[0], y+offset[1]); side != nil && side.IsTunnel && side.Soil != types.Empty {
+			wettest = max(wettest, side.Moisture)
+		}
+	}
+	if target := wettest - soakLoss; target > cell.Moisture {
+		cell.Moisture = min(target, cell.Moisture+soakRate)
+	}
+}
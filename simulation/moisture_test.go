@@ -0,0 +1,93 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestRainSoaksIntoEntrances(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	entrance := world.GetCell(10, surfaceRow+1)
+	entrance.IsTunnel = true
+	soil := world.GetCell(12, surfaceRow+1)
+	before := entrance.Moisture
+
+	world.Ticks = rainInterval
+	updateMoisture(world)
+
+	if entrance.Moisture <= before {
+		t.Errorf("Rain should soak into an open entrance, moisture %d", entrance.Moisture)
+	}
+	if soil.Moisture != world.BaseMoisture(surfaceRow+1) {
+		t.Errorf("Rain should not soak into undug soil, moisture %d", soil.Moisture)
+	}
+
+	world.Ticks = rainDuration + seepInterval
+	if raining(world) {
+		t.Error("There should be no rain before the first interval has passed")
+	}
+}
+
+func TestWaterRunsDownTunnels(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	for y := 10; y <= 12; y++ {
+		world.GetCell(5, y).IsTunnel = true
+	}
+	world.GetCell(5, 10).Moisture = 100
+	bottom := world.GetCell(5, 12).Moisture
+
+	world.Ticks = seepInterval
+	updateMoisture(world)
+	if world.GetCell(5, 11).Moisture <= world.BaseMoisture(11) {
+		t.Error("Water should run down into the tunnel below")
+	}
+
+	for i := 0; i < 5; i++ {
+		world.Ticks += seepInterval
+		updateMoisture(world)
+	}
+	if world.GetCell(5, 12).Moisture <= bottom {
+		t.Error("Water should collect at the bottom of the shaft")
+	}
+	if world.GetCell(6, 12).Moisture <= world.BaseMoisture(12) {
+		t.Error("Soil beside wet tunnel should soak up some water")
+	}
+}
+
+func TestGroundDriesOut(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	cell := world.GetCell(8, 8)
+	cell.Moisture = world.BaseMoisture(8) + 3
+
+	for i := 1; i <= 5; i++ {
+		world.Ticks = i * seepInterval
+		updateMoisture(world)
+	}
+
+	if cell.Moisture != world.BaseMoisture(8) {
+		t.Errorf("Expected the soil back at %d, got %d", world.BaseMoisture(8), cell.Moisture)
+	}
+}
+
+func TestWaterFloodsGardenBelowTunnel(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	garden := foundGarden(world, colony)
+	above := world.GetCell(garden.Position.X, garden.Position.Y-1)
+	above.IsTunnel = true
+
+	// Keep the tunnel over the garden running with water
+	for i := 1; i <= 20 && colony.Garden != nil; i++ {
+		above.Moisture = 100
+		world.Ticks = i * seepInterval
+		updateMoisture(world)
+		updateGarden(world, colony)
+	}
+
+	if colony.Garden != nil {
+		t.Errorf("Garden under a flooded tunnel should drown, moisture %d",
+			world.GetCell(garden.Position.X, garden.Position.Y).Moisture)
+	}
+}
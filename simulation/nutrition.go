@@ -0,0 +1,50 @@
+package logic
+
+import "antfarm/types"
+
+// nutrition.go - Which food pays for what
+// Adults run on carbohydrate, burning any sugar first. Brood only grows on
+// protein, and the queen needs protein to lay, so a colony living on grass
+// alone keeps its adults fed but stops raising young. A colony short of one
+// diet sends its foragers out for that kind in particular.
+
+// Diets, in the order each store is drawn down
+var (
+	adultDiet = []types.FoodType{types.Sugar, types.Carbohydrate} // Adult meals
+	broodDiet = []types.FoodType{types.Protein}                   // Larvae rations and eggs
+)
+
+// Stock the colony likes to keep of each diet. Short of one while well off
+// for the other, its foragers go looking for what it is short of
+var (
+	adultReserve = 50 * types.FoodScale // Carbohydrate and sugar
+	broodReserve = 20 * types.FoodScale // Protein
+)
+
+// everyFood is every food type, for foragers with nothing in particular to look for
+var everyFood = []types.FoodType{types.Carbohydrate, types.Protein, types.Sugar}
+
+// wantedFood returns the food types a colony's foragers bring home
+// While the stores are short of one diet and have their reserve of the other,
+// foragers leave the plentiful kind lying and look only for the short one.
+// Otherwise anything will do
+func wantedFood(colony *types.Colony) []types.FoodType {
+	adult, brood := colony.Food.Available(adultDiet...), colony.Food.Available(broodDiet...)
+	switch {
+	case brood < broodReserve && adult >= adultReserve:
+		return broodDiet
+	case adult < adultReserve && brood >= broodReserve:
+		return adultDiet
+	}
+	return everyFood
+}
+
+// wants reports whether a food type is among those listed
+func wants(diet []types.FoodType, foodType types.FoodType) bool {
+	for _, wanted := range diet {
+		if wanted == foodType {
+			return true
+		}
+	}
+	return false
+}
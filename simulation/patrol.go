@@ -0,0 +1,183 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+	"sort"
+)
+
+// patrol.go - Soldiers walking the nest when there is nothing to fight
+// A soldier on patrol walks from waypoint to waypoint around the queen: the
+// entrances, the outer reaches of the tunnels and now and then the queen's
+// chamber. The waypoints are kept in order around the queen, so a patrol goes
+// round the nest in a loop rather than back and forth. Anywhere a threat was
+// seen lately pulls patrols toward it until the sighting goes stale. Soldiers
+// stand down for a short rest every so often, and never go further from the
+// queen than the patrol radius.
+
+// Patrol tuning
+var (
+	patrolRadius    = 12  // Furthest a patrol goes from the queen, in cells either way
+	patrolShift     = 60  // Ticks a soldier patrols before it rests
+	patrolRest      = 10  // Ticks a soldier rests between shifts
+	patrolLookahead = 3   // Waypoints further round the loop a soldier chooses between
+	loopWeight      = 4   // Weight of each waypoint further round the loop
+	chamberWeight   = 2   // Weight of a stop at the queen's chamber
+	threatWeight    = 12  // Weight a fresh sighting adds to waypoints near it
+	threatReach     = 4   // How close a waypoint has to be to a sighting to be drawn to it
+	threatMemory    = 300 // Ticks before a sighting stops drawing patrols
+)
+
+// patrol moves a soldier one step along its patrol, or rests it
+// Returns false if the nest has nowhere to patrol
+func patrol(world *types.World, colony *types.Colony, soldier *types.SoldierAnt) bool {
+	// A shift ends in a rest, and a rest ends in a new shift
+	if soldier.PatrolTimer <= 0 {
+		soldier.IsPatrolling = !soldier.IsPatrolling
+		if soldier.IsPatrolling {
+			soldier.PatrolTimer = patrolShift
+		} else {
+			soldier.PatrolTimer = patrolRest
+		}
+	}
+	soldier.PatrolTimer--
+	if !soldier.IsPatrolling {
+		soldier.CurrentAction = "resting"
+		return true
+	}
+
+	// Strayed too far chasing something, so head back toward the queen
+	if !withinPatrol(colony, soldier.Position) {
+		soldier.TargetPosition = nil
+		soldier.CurrentAction = "returning to patrol"
+		return pathfinder.MoverFor(colony, types.Soldier).Toward(world, colony, soldier, colony.QueenPosition)
+	}
+
+	if soldier.TargetPosition == nil || pathfinder.IsAdjacentOrSame(soldier.Position, *soldier.TargetPosition) {
+		waypoint, ok := nextWaypoint(world, colony, soldier)
+		if !ok {
+			return false
+		}
+		soldier.TargetPosition = &waypoint
+	}
+
+	soldier.CurrentAction = "patrolling"
+	if !pathfinder.MoverFor(colony, types.Soldier).Toward(world, colony, soldier, *soldier.TargetPosition) {
+		soldier.TargetPosition = nil // Try somewhere else next tick
+	}
+	return true
+}
+
+// withinPatrol reports whether a cell is inside the patrol radius of the queen
+func withinPatrol(colony *types.Colony, pos types.Position) bool {
+	dx, dy := pos.X-colony.QueenPosition.X, pos.Y-colony.QueenPosition.Y
+	return max(dx, -dx, dy, -dy) <= patrolRadius
+}
+
+// patrolWaypoints lists the places a patrol goes, in order around the queen:
+// every entrance and the outermost third of the tunnels within the patrol
+// radius. Only cells with an open way back to the queen count
+func patrolWaypoints(world *types.World, colony *types.Colony) []types.Position {
+	field := colony.HomeField
+	if field == nil {
+		return nil
+	}
+
+	queen := colony.QueenPosition
+	var cells []types.Position
+	farthest := 0
+	for y := queen.Y - patrolRadius; y <= queen.Y+patrolRadius; y++ {
+		for x := queen.X - patrolRadius; x <= queen.X+patrolRadius; x++ {
+			if d := field.At(x, y); d != types.Unreachable && y > surfaceRow {
+				cells = append(cells, types.Position{X: x, Y: y})
+				farthest = max(farthest, d)
+			}
+		}
+	}
+
+	var waypoints []types.Position
+	for _, pos := range cells {
+		entrance := pos.Y == surfaceRow+1
+		outer := field.At(pos.X, pos.Y)*3 >= farthest*2
+		if (entrance || outer) && pos != queen {
+			waypoints = append(waypoints, pos)
+		}
+	}
+	sort.SliceStable(waypoints, func(i, j int) bool {
+		return clockwise(offset(queen, waypoints[i]), offset(queen, waypoints[j]))
+	})
+	return waypoints
+}
+
+// nextWaypoint picks where a soldier patrols to next
+// It chooses at random between the next few waypoints round the loop from
+// where it stands, the queen's chamber, and any waypoint near a fresh
+// sighting, weighted so the loop is the usual way and threats draw it off
+func nextWaypoint(world *types.World, colony *types.Colony, soldier *types.SoldierAnt) (types.Position, bool) {
+	waypoints := patrolWaypoints(world, colony)
+	if len(waypoints) == 0 {
+		return types.Position{}, false
+	}
+
+	// The first waypoint round the loop past where the soldier stands
+	here := offset(colony.QueenPosition, soldier.Position)
+	start := sort.Search(len(waypoints), func(i int) bool {
+		return clockwise(here, offset(colony.QueenPosition, waypoints[i]))
+	})
+
+	weights := make([]int, len(waypoints))
+	for i := 0; i < patrolLookahead && i < len(waypoints); i++ {
+		weights[(start+i)%len(waypoints)] += loopWeight
+	}
+	for i, pos := range waypoints {
+		weights[i] += threatPull(world, colony, pos)
+	}
+
+	total := chamberWeight
+	for _, w := range weights {
+		total += w
+	}
+	roll := int(world.Random.Below(uint32(total)))
+	for i, w := range weights {
+		if roll < w {
+			return waypoints[i], true
+		}
+		roll -= w
+	}
+	return colony.QueenPosition, true
+}
+
+// threatPull is how strongly recent sightings near a waypoint draw patrols
+// to it. A sighting counts for less the older it gets
+func threatPull(world *types.World, colony *types.Colony, pos types.Position) int {
+	pull := 0
+	for _, s := range colony.Sightings {
+		age := world.Ticks - s.Tick
+		dx, dy := s.Position.X-pos.X, s.Position.Y-pos.Y
+		if age < threatMemory && max(dx, -dx, dy, -dy) <= threatReach {
+			pull += threatWeight * (threatMemory - age) / threatMemory
+		}
+	}
+	return pull
+}
+
+// offset returns where a cell lies relative to the queen
+func offset(queen, pos types.Position) types.Position {
+	return types.Position{X: pos.X - queen.X, Y: pos.Y - queen.Y}
+}
+
+// clockwise reports whether offset a comes before offset b going clockwise on
+// screen from straight right of the queen. Integer only: the half of the
+// circle first, then the sign of the cross product
+func clockwise(a, b types.Position) bool {
+	if lowerHalf(a) != lowerHalf(b) {
+		return lowerHalf(a)
+	}
+	return a.X*b.Y-a.Y*b.X > 0
+}
+
+// lowerHalf reports whether an offset points right or anywhere below the
+// queen, the first half of a clockwise turn on screen (y grows downward)
+func lowerHalf(p types.Position) bool {
+	return p.Y > 0 || (p.Y == 0 && p.X > 0)
+}
@@ -0,0 +1,129 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+// patrolNest returns a world with a colony whose queen sits at (20,15), a shaft
+// up to the surface above her and a long gallery either side of her
+func patrolNest() (*types.World, *types.Colony) {
+	world := types.NewWorld(60, 40, random.New(1))
+	for y := 2; y <= 15; y++ {
+		world.GetCell(20, y).IsTunnel = true
+	}
+	for x := 2; x <= 45; x++ {
+		world.GetCell(x, 15).IsTunnel = true
+	}
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	colony.HomeField = types.NewDistanceField(world, colony.QueenPosition)
+	return world, colony
+}
+
+func TestClockwiseOrder(t *testing.T) {
+	order := []types.Position{{X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: -1, Y: 0}, {X: 0, Y: -1}, {X: 1, Y: -1}}
+	for i := 0; i < len(order); i++ {
+		for j := 0; j < len(order); j++ {
+			if got := clockwise(order[i], order[j]); got != (i < j) {
+				t.Errorf("clockwise(%v, %v) = %v", order[i], order[j], got)
+			}
+		}
+	}
+}
+
+func TestPatrolWaypoints(t *testing.T) {
+	world, colony := patrolNest()
+
+	waypoints := patrolWaypoints(world, colony)
+
+	has := map[types.Position]bool{}
+	for _, pos := range waypoints {
+		has[pos] = true
+		if !withinPatrol(colony, pos) {
+			t.Errorf("Waypoint %v is outside the patrol radius", pos)
+		}
+	}
+	if !has[types.Position{X: 20, Y: 3}] {
+		t.Error("The entrance above the queen should be a waypoint")
+	}
+	if !has[types.Position{X: 32, Y: 15}] || !has[types.Position{X: 8, Y: 15}] {
+		t.Error("Both far ends of the gallery inside the radius should be waypoints")
+	}
+	if has[types.Position{X: 21, Y: 15}] || has[colony.QueenPosition] {
+		t.Error("Cells beside the queen are not on the perimeter")
+	}
+}
+
+func TestSoldierRestsBetweenShifts(t *testing.T) {
+	world, colony := patrolNest()
+	soldier := types.NewSoldier(5, 22, 15, "Red")
+	world.GetCell(22, 15).Occupant = soldier
+
+	for i := 0; i < patrolShift; i++ {
+		patrol(world, colony, soldier)
+		if soldier.CurrentAction == "resting" {
+			t.Fatalf("Soldier rested after only %d ticks of its shift", i)
+		}
+	}
+	patrol(world, colony, soldier)
+	if soldier.CurrentAction != "resting" || soldier.IsPatrolling {
+		t.Errorf("Soldier should rest at the end of its shift, doing %q", soldier.CurrentAction)
+	}
+	for i := 0; i < patrolRest; i++ {
+		patrol(world, colony, soldier)
+	}
+	if !soldier.IsPatrolling {
+		t.Error("Soldier should be back on patrol after its rest")
+	}
+}
+
+func TestPatrolStaysNearQueen(t *testing.T) {
+	world, colony := patrolNest()
+	soldier := types.NewSoldier(5, 22, 15, "Red")
+	world.GetCell(22, 15).Occupant = soldier
+	start := soldier.Position
+	moved := false
+
+	for i := 0; i < 300; i++ {
+		patrol(world, colony, soldier)
+		if !withinPatrol(colony, soldier.Position) {
+			t.Fatalf("Tick %d: soldier wandered out to %v", i, soldier.Position)
+		}
+		moved = moved || soldier.Position != start
+	}
+	if !moved {
+		t.Error("Soldier should have walked its patrol")
+	}
+}
+
+func TestStraySoldierHeadsBack(t *testing.T) {
+	world, colony := patrolNest()
+	soldier := types.NewSoldier(5, 40, 15, "Red")
+	world.GetCell(40, 15).Occupant = soldier
+
+	patrol(world, colony, soldier)
+
+	if soldier.Position.X != 39 || soldier.CurrentAction != "returning to patrol" {
+		t.Errorf("Soldier beyond the radius should head back, at %v doing %q",
+			soldier.Position, soldier.CurrentAction)
+	}
+}
+
+func TestSightingsDrawPatrols(t *testing.T) {
+	world, colony := patrolNest()
+	world.Ticks = 1000
+	waypoint := types.Position{X: 8, Y: 15}
+
+	if pull := threatPull(world, colony, waypoint); pull != 0 {
+		t.Fatalf("No sightings should pull nothing, got %d", pull)
+	}
+	colony.ReportThreat(types.Position{X: 6, Y: 15}, world.Ticks)
+	if pull := threatPull(world, colony, waypoint); pull != threatWeight {
+		t.Errorf("A fresh sighting should pull %d, got %d", threatWeight, pull)
+	}
+	world.Ticks += threatMemory
+	if pull := threatPull(world, colony, waypoint); pull != 0 {
+		t.Errorf("A stale sighting should pull nothing, got %d", pull)
+	}
+}
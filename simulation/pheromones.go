@@ -0,0 +1,38 @@
+package logic
+
+import (
+	"antfarm/types"
+)
+
+// pheromones.go - Evaporation and spread of every pheromone in the world
+// The fields themselves live on the world (see types/pheromone.go). This is
+// where each pheromone's rates are set and where ants lay it down.
+
+// pheromoneRates sets how fast each pheromone fades and spreads, per thousand per tick
+var pheromoneRates = [types.NumPheromoneTypes]struct {
+	evaporation int // Share of each cell's level lost per tick
+	diffusion   int // Share of each cell's level handed to its neighbours per tick
+}{
+	types.Trail:     {evaporation: 5, diffusion: 0},     // Stays on the path it was laid along, lingers a few hundred ticks
+	types.Alarm:     {evaporation: 150, diffusion: 400}, // Spreads fast, gone in a few dozen ticks
+	types.Territory: {evaporation: 2, diffusion: 0},     // Stays where it was laid and fades slowly
+}
+
+// Trail recruitment
+var (
+	trailDeposit         = 100 // Trail a loaded forager lays on every step home
+	trailFollowThreshold = 10  // Weakest trail an unloaded worker will follow
+)
+
+// updatePheromones evaporates and spreads every pheromone by one tick
+func updatePheromones(world *types.World) {
+	for kind := types.PheromoneType(0); kind < types.NumPheromoneTypes; kind++ {
+		rates := pheromoneRates[kind]
+		world.Pheromones.Step(kind, rates.evaporation, rates.diffusion)
+	}
+}
+
+// layPheromone has an ant lay pheromone in the cell it is standing in
+func layPheromone(world *types.World, ant *types.Ant, kind types.PheromoneType, amount int) {
+	world.Pheromones.Deposit(ant.ColonyID, kind, ant.Position.X, ant.Position.Y, amount)
+}
@@ -0,0 +1,90 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestAntLaysPheromoneWhereItStands(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	ant := types.NewAnt(1, types.Worker, 12, 8, "Red", 100, 500)
+
+	layPheromone(world, ant, types.Trail, 100)
+
+	if got := world.Pheromones.Level("Red", types.Trail, 12, 8); got != 100 {
+		t.Errorf("Expected trail 100 at the ant, got %d", got)
+	}
+}
+
+func TestAlarmFadesFasterThanTrail(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	ant := types.NewAnt(1, types.Worker, 12, 8, "Red", 100, 500)
+	layPheromone(world, ant, types.Trail, 500)
+	layPheromone(world, ant, types.Alarm, 500)
+
+	for i := 0; i < 10; i++ {
+		updatePheromones(world)
+	}
+
+	trail := world.Pheromones.Level("Red", types.Trail, 12, 8)
+	alarm := world.Pheromones.Level("Red", types.Alarm, 12, 8)
+	if alarm >= trail {
+		t.Errorf("Alarm should fade faster than trail: alarm %d, trail %d", alarm, trail)
+	}
+}
+
+func TestPheromonesAreDeterministic(t *testing.T) {
+	lay := func() *types.World {
+		world := types.NewWorld(40, 30, random.New(1))
+		ant := types.NewAnt(1, types.Worker, 12, 8, "Red", 100, 500)
+		layPheromone(world, ant, types.Trail, 700)
+		for i := 0; i < 50; i++ {
+			updatePheromones(world)
+		}
+		return world
+	}
+
+	a, b := lay(), lay()
+	for y := 0; y < 30; y++ {
+		for x := 0; x < 40; x++ {
+			if a.Pheromones.Level("Red", types.Trail, x, y) != b.Pheromones.Level("Red", types.Trail, x, y) {
+				t.Fatalf("Fields differ at (%d,%d)", x, y)
+			}
+		}
+	}
+}
+
+func TestLoadedWorkerLaysTrailHome(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+
+	worker := SpawnWorker(colony, 20, 10)
+	PlaceAnt(world, worker)
+	worker.CarryingFood = true
+	worker.FoodAmount = 5 * types.FoodScale
+
+	workerBehavior(world, colony, worker)
+
+	if got := world.Pheromones.Level("Red", types.Trail, 20, 10); got != trailDeposit {
+		t.Errorf("Expected trail %d where the worker stood, got %d", trailDeposit, got)
+	}
+}
+
+func TestDepositIsCounted(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+
+	worker := SpawnWorker(colony, 20, 14)
+	PlaceAnt(world, worker)
+	worker.CarryingFood = true
+	worker.FoodAmount = 5 * types.FoodScale
+
+	workerBehavior(world, colony, worker)
+
+	if colony.Deposits != 1 || worker.Deposits != 1 {
+		t.Errorf("Expected 1 deposit for colony and worker, got %d and %d", colony.Deposits, worker.Deposits)
+	}
+}
@@ -0,0 +1,45 @@
+package logic
+
+import (
+	"antfarm/types"
+)
+
+// prey.go - Insects for the colony to hunt
+// Grass and seeds are everywhere, but protein does not grow back. Small
+// insects keep blundering onto the surface and dying there, and a spider the
+// ants kill leaves its carcass behind. Either lies as a protein pellet until
+// a forager carries it home or it rots away.
+
+// Prey tuning
+var (
+	preyInterval  = 200                  // Ticks between insects turning up dead on the surface
+	preyFood      = 5 * types.FoodScale  // Food in a dead insect
+	spiderCarcass = 10 * types.FoodScale // Food in a dead spider
+)
+
+// dropPrey leaves a dead insect on a random surface cell every preyInterval
+// ticks. A cell that already has food on it is left as it is
+func dropPrey(world *types.World) {
+	if world.Ticks == 0 || world.Ticks%preyInterval != 0 {
+		return
+	}
+	x := int(world.Random.Below(uint32(world.Width)))
+	if cell := world.GetCell(x, surfaceRow); cell != nil && cell.Food <= 0 {
+		leaveProtein(cell, preyFood)
+	}
+}
+
+// leaveCarcass turns a spider the ants have killed into a protein pellet
+// where it fell
+func leaveCarcass(world *types.World, spider *types.Critter) {
+	if cell := world.GetCell(spider.Position.X, spider.Position.Y); cell != nil && cell.Food <= 0 {
+		leaveProtein(cell, spiderCarcass)
+	}
+}
+
+// leaveProtein puts a fresh protein pellet on a cell
+func leaveProtein(cell *types.Cell, amount int) {
+	cell.Food = amount
+	cell.FoodType = types.Protein
+	cell.Rot = 0
+}
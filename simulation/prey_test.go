@@ -0,0 +1,64 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestPreyTurnsUpOnTheSurface(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	for x := 0; x < world.Width; x++ {
+		world.GetCell(x, surfaceRow).Food = 0
+	}
+
+	world.Ticks = preyInterval
+	dropPrey(world)
+
+	found := 0
+	for x := 0; x < world.Width; x++ {
+		if cell := world.GetCell(x, surfaceRow); cell.Food > 0 {
+			found++
+			if cell.FoodType != types.Protein || cell.Food != preyFood {
+				t.Errorf("Expected %d protein at x=%d, got %d of type %d", preyFood, x, cell.Food, cell.FoodType)
+			}
+		}
+	}
+	if found != 1 {
+		t.Errorf("Expected one dead insect on the surface, found %d", found)
+	}
+}
+
+func TestKilledSpiderLeavesCarcass(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	world.GetCell(10, surfaceRow).Food = 0
+	spider := types.NewSpider(world.NextCritterID, 10, surfaceRow)
+	AddCritter(world, spider)
+	spider.Health = 0
+	world.Ticks = 1 // Off the beat critters arrive and move on
+
+	updateCritters(world)
+
+	cell := world.GetCell(10, surfaceRow)
+	if cell.Critter != nil {
+		t.Fatal("Dead spider should be cleared away")
+	}
+	if cell.Food != spiderCarcass || cell.FoodType != types.Protein {
+		t.Errorf("Expected a %d protein carcass, got %d of type %d", spiderCarcass, cell.Food, cell.FoodType)
+	}
+}
+
+func TestSpiderMovingOnLeavesNothing(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	world.GetCell(10, surfaceRow).Food = 0
+	spider := types.NewSpider(world.NextCritterID, 10, surfaceRow)
+	AddCritter(world, spider)
+	spider.Age = spiderLifetime
+	world.Ticks = 1
+
+	updateCritters(world)
+
+	if cell := world.GetCell(10, surfaceRow); cell.Food != 0 {
+		t.Errorf("A spider that wandered off should leave no food, got %d", cell.Food)
+	}
+}
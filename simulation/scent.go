@@ -0,0 +1,94 @@
+package logic
+
+import (
+	"antfarm/types"
+)
+
+// scent.go - Food scent spreading through soil and tunnels
+// Every food pellet gives off a scent that spreads out from it, weakening with
+// each cell it passes through. Open tunnel and air barely dull it, packed
+// soil dulls it more, and rock stops it dead. Each food type has a field of
+// its own, so a forager can smell out the kind its colony is short of. Where
+// two scents of a type overlap the stronger one wins, so each field always
+// slopes up toward the nearest pellet of that type.
+
+// Scent tuning
+var (
+	// scentStrength is how strong a pellet of each food type smells. A pellet's
+	// scent reaches this many cells of open tunnel before it fades out
+	scentStrength = [types.NumFoodTypes]int{
+		types.Carbohydrate: 8,
+		types.Protein:      12, // Prey smells strongest
+		types.Sugar:        10,
+	}
+
+	// scentSoilCost is how much scent is lost passing into a cell of each soil
+	// type. 0 means scent cannot pass at all. Dug tunnels always cost 1
+	scentSoilCost = [...]int{
+		types.Sand:  2,
+		types.Dirt:  3,
+		types.Clay:  4,
+		types.Rock:  0,
+		types.Empty: 1,
+	}
+)
+
+// scentCost returns how much scent is lost passing into a cell, or 0 if it cannot pass
+func scentCost(cell *types.Cell) int {
+	if cell.IsTunnel {
+		return 1
+	}
+	return scentSoilCost[cell.Soil]
+}
+
+// spreadScent rebuilds the world's food scent fields from every pellet lying in it
+func spreadScent(world *types.World) {
+	for foodType := types.FoodType(0); foodType < types.NumFoodTypes; foodType++ {
+		spreadScentOf(world, foodType)
+	}
+}
+
+// spreadScentOf rebuilds the scent field of one food type
+// Cells are settled strongest first, the way Dijkstra settles nearest first,
+// so every cell ends up with the strongest scent that can reach it
+func spreadScentOf(world *types.World, foodType types.FoodType) {
+	scent := world.Scent[foodType]
+	for i := range scent {
+		scent[i] = 0
+	}
+
+	// One bucket of cell indexes per scent level
+	strength := scentStrength[foodType]
+	buckets := make([][]int, strength+1)
+	for i := range world.Cells {
+		cell := &world.Cells[i]
+		if cell.Food > 0 && cell.FoodType == foodType {
+			scent[i] = strength
+			buckets[strength] = append(buckets[strength], i)
+		}
+	}
+
+	for level := strength; level > 1; level-- {
+		for _, i := range buckets[level] {
+			if scent[i] != level {
+				continue // Already reached by a stronger scent
+			}
+			x, y := i%world.Width, i/world.Width
+			for _, offset := range [4][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}} {
+				cell := world.GetCell(x+offset[0], y+offset[1])
+				if cell == nil {
+					continue
+				}
+				cost := scentCost(cell)
+				if cost == 0 {
+					continue
+				}
+				n := world.Index(x+offset[0], y+offset[1])
+				if next := level - cost; next > scent[n] {
+					scent[n] = next
+					buckets[next] = append(buckets[next], n)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+// scentWorld returns a world with a clear row of open tunnel at y=10
+func scentWorld() *types.World {
+	world := types.NewWorld(40, 30, random.New(1))
+	for i := range world.Cells {
+		world.Cells[i].Food = 0
+	}
+	for x := 0; x < 40; x++ {
+		world.GetCell(x, 10).IsTunnel = true
+	}
+	return world
+}
+
+func TestScentFadesWithDistance(t *testing.T) {
+	world := scentWorld()
+	world.GetCell(20, 10).Food = 5 * types.FoodScale
+	world.GetCell(20, 10).FoodType = types.Carbohydrate
+
+	spreadScent(world)
+
+	strength := scentStrength[types.Carbohydrate]
+	if got := world.ScentAt(20, 10); got != strength {
+		t.Errorf("Expected %d at the pellet, got %d", strength, got)
+	}
+	if got := world.ScentAt(23, 10); got != strength-3 {
+		t.Errorf("Expected %d three cells down the tunnel, got %d", strength-3, got)
+	}
+	if got := world.ScentAt(20+strength, 10); got != 0 {
+		t.Errorf("Scent should have faded out by %d cells, got %d", strength, got)
+	}
+}
+
+func TestScentIsDulledBySoil(t *testing.T) {
+	world := scentWorld()
+	world.GetCell(20, 10).Food = 5 * types.FoodScale
+
+	spreadScent(world)
+
+	tunnel := world.ScentAt(21, 10)
+	soil := world.ScentAt(20, 11)
+	if soil >= tunnel {
+		t.Errorf("Scent should be weaker through soil: soil %d, tunnel %d", soil, tunnel)
+	}
+}
+
+func TestScentBlockedByRock(t *testing.T) {
+	world := scentWorld()
+	world.GetCell(20, 10).Food = 5 * types.FoodScale
+	world.GetCell(21, 10).Soil = types.Rock
+	world.GetCell(21, 10).IsTunnel = false
+
+	spreadScent(world)
+
+	if got := world.ScentAt(21, 10); got != 0 {
+		t.Errorf("Scent should not get into rock, got %d", got)
+	}
+}
+
+func TestProteinSmellsStronger(t *testing.T) {
+	world := scentWorld()
+	world.GetCell(10, 10).Food = 5 * types.FoodScale
+	world.GetCell(10, 10).FoodType = types.Carbohydrate
+	world.GetCell(30, 10).Food = 5 * types.FoodScale
+	world.GetCell(30, 10).FoodType = types.Protein
+
+	spreadScent(world)
+
+	if world.ScentAt(33, 10) <= world.ScentAt(13, 10) {
+		t.Error("Protein should smell stronger than carbohydrate at the same distance")
+	}
+}
+
+func TestStrongerScentWins(t *testing.T) {
+	world := scentWorld()
+	world.GetCell(18, 10).Food = 5 * types.FoodScale
+	world.GetCell(22, 10).Food = 5 * types.FoodScale
+
+	spreadScent(world)
+
+	// Overlapping scents do not add up
+	if got := world.ScentAt(20, 10); got != scentStrength[types.Carbohydrate]-2 {
+		t.Errorf("Expected %d between two pellets, got %d", scentStrength[types.Carbohydrate]-2, got)
+	}
+}
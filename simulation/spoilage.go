@@ -0,0 +1,211 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+)
+
+// spoilage.go - Food decay on the ground and in the stores
+// Pellets left lying out rot away if nobody collects them, and the colony
+// stockpile loses a share of itself to mould every so often. Damp cells rot
+// food faster, so a store dug into wet soil is worth less than a dry one, and
+// the colony moves its stores out of the granary while it is much damper than
+// the queen's chamber. Moving them is work: a few free workers carry the food
+// over a load at a time, and the stores only change place once the last load
+// is in.
+
+// Spoilage tuning
+var (
+	pelletRotLimit = 1500 // Rot at which a pellet lying out has rotted away
+	dampRotFactor  = 25   // Each this much moisture adds one more rot per tick
+
+	storeSpoilInterval = 100 // Ticks between stockpile spoilage checks
+	storeSpoilPermille = 5   // Share of each food type lost per check in a bone dry store, per thousand
+	dampSpoilPermille  = 20  // Extra share lost per check in a fully flooded store, per thousand
+	storeDampMargin    = 10  // How much damper than the queen's chamber the granary can be and still hold the stores
+
+	storeHaulers = 2                    // Most free workers carrying the stores to a new place at once
+	haulLoad     = 10 * types.FoodScale // Food a worker carries per trip when moving the stores
+)
+
+// rotFood ages every food pellet lying in the world and removes the ones that
+// have rotted away. Rotten food is worthless, so the cell is left bare
+func rotFood(world *types.World) {
+	for i := range world.Cells {
+		cell := &world.Cells[i]
+		if cell.Food <= 0 {
+			continue
+		}
+
+		cell.Rot += 1 + cell.Moisture/dampRotFactor
+		if cell.Rot >= pelletRotLimit {
+			cell.Food = 0
+			cell.Rot = 0
+		}
+	}
+}
+
+// spoilRate returns the share of a store lost per spoilage check, per thousand
+func spoilRate(moisture int) int {
+	return storeSpoilPermille + moisture*dampSpoilPermille/100
+}
+
+// spoilStore takes the colony's periodic loss to decay out of every food type
+// How much goes depends on how damp the store's cell is. Losses round up, so
+// even a small store cannot sit forever without spoiling
+func spoilStore(world *types.World, colony *types.Colony) {
+	if world.Ticks == 0 || world.Ticks%storeSpoilInterval != 0 {
+		return
+	}
+	moveStores(world, colony)
+
+	store := colony.StorePosition()
+	cell := world.GetCell(store.X, store.Y)
+	if cell == nil {
+		return
+	}
+
+	rate := spoilRate(cell.Moisture)
+	for foodType := types.FoodType(0); foodType < types.NumFoodTypes; foodType++ {
+		amount := colony.Food[foodType]
+		if amount <= 0 {
+			continue
+		}
+
+		lost := (amount*rate + 999) / 1000
+		colony.Food[foodType] -= lost
+		colony.Spoiled += lost
+	}
+}
+
+// moveStores sets the colony's workers carrying its food into the granary
+// once it has been dug, or back beside the queen while the granary is much
+// damper than her chamber. A move already under way to the same place carries
+// on, and one no longer wanted is called off with the food still where it was
+func moveStores(world *types.World, colony *types.Colony) {
+	var stores *types.Chamber
+	if granary := colony.Chamber(types.Granary); granary != nil && granary.Open &&
+		moistureAt(world, granary.Center) <= moistureAt(world, colony.QueenPosition)+storeDampMargin {
+		stores = granary
+	}
+	if colony.Haul != nil && colony.Haul.To == stores {
+		return
+	}
+	colony.Haul = nil
+	if stores == colony.Stores {
+		return
+	}
+
+	colony.Haul = &types.StoresHaul{To: stores, Moved: 0}
+	finishHaul(world, colony)
+}
+
+// haulStores has one of the colony's first few free foragers carry a load of
+// the stores to where they are moving, or go and fetch one. Returns false if
+// the worker has no part in a move
+func haulStores(world *types.World, colony *types.Colony, worker *types.WorkerAnt) bool {
+	haul := colony.Haul
+	if worker.Hauling > 0 {
+		if haul == nil {
+			worker.Hauling = 0 // The move was called off, so the load counts where it was
+			return false
+		}
+		to := haulDestination(colony, haul)
+		if pathfinder.IsAdjacentOrSame(worker.Position, to) {
+			haul.Moved += worker.Hauling
+			worker.Hauling = 0
+			worker.CurrentAction = "moved a load of the stores"
+			finishHaul(world, colony)
+			return true
+		}
+		worker.CurrentAction = "carrying the stores"
+		if !pathfinder.MoverFor(colony, types.Worker).Toward(world, colony, worker, to) {
+			worker.CurrentAction = "stuck carrying the stores"
+		}
+		return true
+	}
+
+	if haul == nil || !isHauler(colony, worker) {
+		return false
+	}
+	left := storesLeft(colony, haul)
+	if left <= 0 {
+		return false
+	}
+	store := colony.StorePosition()
+	if pathfinder.IsAdjacentOrSame(worker.Position, store) {
+		worker.Hauling = min(haulLoad, left)
+		worker.CurrentAction = "picked up a load of the stores"
+		return true
+	}
+	worker.CurrentAction = "going to move the stores"
+	pathfinder.MoverFor(colony, types.Worker).Toward(world, colony, worker, store)
+	return true
+}
+
+// isHauler reports whether a worker is one of the first storeHaulers free
+// foragers, who do the carrying while the stores move
+func isHauler(colony *types.Colony, worker *types.WorkerAnt) bool {
+	haulers := 0
+	for _, w := range colony.Workers {
+		if w.Task != types.Foraging || w.CarryingFood || w.CarryingCorpse {
+			continue
+		}
+		if w == worker {
+			return true
+		}
+		haulers++
+		if haulers >= storeHaulers {
+			return false
+		}
+	}
+	return false
+}
+
+// storesLeft is how much food still waits to be carried: whatever the colony
+// holds that is neither moved already nor on its way
+func storesLeft(colony *types.Colony, haul *types.StoresHaul) int {
+	left := colony.Food.Total() - haul.Moved
+	for _, worker := range colony.Workers {
+		left -= worker.Hauling
+	}
+	return left
+}
+
+// haulDestination returns where a move is carrying the stores to
+func haulDestination(colony *types.Colony, haul *types.StoresHaul) types.Position {
+	if haul.To != nil {
+		return haul.To.Center
+	}
+	return colony.QueenPosition
+}
+
+// finishHaul settles the stores in their new place once nothing is left to
+// carry and no load is still on its way. The move goes in the event log
+func finishHaul(world *types.World, colony *types.Colony) {
+	haul := colony.Haul
+	if haul == nil || storesLeft(colony, haul) > 0 {
+		return
+	}
+	for _, worker := range colony.Workers {
+		if worker.Hauling > 0 {
+			return
+		}
+	}
+
+	colony.Stores = haul.To
+	colony.Haul = nil
+	where := types.QueenChamber.String()
+	if haul.To != nil {
+		where = haul.To.Kind.String()
+	}
+	world.ReportEvent("%s moved its stores to the %s", colony.Name, where)
+}
+
+// moistureAt returns how damp a cell is, or 0 outside the world
+func moistureAt(world *types.World, pos types.Position) int {
+	if cell := world.GetCell(pos.X, pos.Y); cell != nil {
+		return cell.Moisture
+	}
+	return 0
+}
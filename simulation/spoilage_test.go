@@ -0,0 +1,179 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestPelletRotsAway(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	cell := world.GetCell(10, 1)
+	cell.Food = 5 * types.FoodScale
+	cell.Rot = pelletRotLimit - 1
+
+	rotFood(world)
+
+	if cell.Food != 0 {
+		t.Errorf("Pellet should have rotted away, still has %d food", cell.Food)
+	}
+}
+
+func TestDampPelletRotsFaster(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	dry := world.GetCell(10, 1)
+	dry.Food = 5 * types.FoodScale
+	dry.Moisture = 0
+	damp := world.GetCell(12, 1)
+	damp.Food = 5 * types.FoodScale
+	damp.Moisture = 80
+
+	rotFood(world)
+
+	if damp.Rot <= dry.Rot {
+		t.Errorf("Damp pellet should rot faster: damp %d, dry %d", damp.Rot, dry.Rot)
+	}
+}
+
+func TestStoreSpoilsOnInterval(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	before := colony.Food.Total()
+
+	world.Ticks = storeSpoilInterval - 1
+	spoilStore(world, colony)
+	if colony.Food.Total() != before {
+		t.Error("Store should not spoil between checks")
+	}
+
+	world.Ticks = storeSpoilInterval
+	spoilStore(world, colony)
+
+	lost := before - colony.Food.Total()
+	if lost <= 0 {
+		t.Fatal("Store should have lost some food to spoilage")
+	}
+	if colony.Spoiled != lost {
+		t.Errorf("Expected %d spoiled, got %d", lost, colony.Spoiled)
+	}
+}
+
+func TestDampStoreSpoilsFaster(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	dry := types.NewColony("Red", 10, 15, types.ColonyRed)
+	damp := types.NewColony("Blue", 30, 15, types.ColonyBlue)
+	AddColony(world, dry)
+	AddColony(world, damp)
+	world.GetCell(10, 15).Moisture = 0
+	world.GetCell(30, 15).Moisture = 80
+
+	world.Ticks = storeSpoilInterval
+	spoilStore(world, dry)
+	spoilStore(world, damp)
+
+	if damp.Spoiled <= dry.Spoiled {
+		t.Errorf("Damp store should spoil faster: damp %d, dry %d", damp.Spoiled, dry.Spoiled)
+	}
+}
+
+// haulUntilMoved runs the colony's first worker until the stores settle in
+// the chamber given, or gives up after a while
+func haulUntilMoved(world *types.World, colony *types.Colony, to *types.Chamber) bool {
+	worker := colony.Workers[0]
+	for i := 0; i < 1000; i++ {
+		if colony.Haul == nil {
+			return colony.Stores == to
+		}
+		world.Ticks++
+		workerBehavior(world, colony, worker)
+	}
+	return false
+}
+
+func TestStoresMoveOutOfDampGranary(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	planNest(world, colony)
+	granary := colony.Chamber(types.Granary)
+	digOut(world, colony, granary)
+	planNest(world, colony)
+	food := colony.Food.Total()
+
+	world.Ticks = storeSpoilInterval
+	moveStores(world, colony)
+	if colony.Haul == nil || colony.Haul.To != granary || colony.Stores != nil {
+		t.Fatal("The stores should start being carried to the dug granary, not jump there")
+	}
+	if !haulUntilMoved(world, colony, granary) || colony.StorePosition() != granary.Center {
+		t.Fatalf("The worker should carry the stores into the granary, at %v", colony.StorePosition())
+	}
+	if colony.Food.Total() != food {
+		t.Errorf("Moving the stores should not lose food, %d left of %d", colony.Food.Total(), food)
+	}
+	if len(world.Events) == 0 || world.Events[len(world.Events)-1].Text != "Red moved its stores to the granary" {
+		t.Errorf("The move should be in the event log, got %v", world.Events)
+	}
+
+	// Rain soaks the granary, so the food goes back beside the queen
+	world.GetCell(granary.Center.X, granary.Center.Y).Moisture = types.FloodedMoisture
+	moveStores(world, colony)
+	if !haulUntilMoved(world, colony, nil) || colony.StorePosition() != colony.QueenPosition {
+		t.Errorf("Stores should be carried out of a sodden granary, at %v", colony.StorePosition())
+	}
+
+	world.GetCell(granary.Center.X, granary.Center.Y).Moisture = world.BaseMoisture(granary.Center.Y)
+	moveStores(world, colony)
+	if colony.Haul == nil || colony.Haul.To != granary {
+		t.Error("Stores should start back once the granary has dried out")
+	}
+}
+
+func TestCalledOffMoveLeavesStoresWhereTheyWere(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	planNest(world, colony)
+	granary := colony.Chamber(types.Granary)
+	digOut(world, colony, granary)
+	planNest(world, colony)
+	worker := colony.Workers[0]
+
+	moveStores(world, colony)
+	workerBehavior(world, colony, worker)
+	if worker.Hauling != haulLoad {
+		t.Fatalf("A worker beside the stores should pick up a load, carrying %d", worker.Hauling)
+	}
+
+	world.GetCell(granary.Center.X, granary.Center.Y).Moisture = types.FloodedMoisture
+	moveStores(world, colony)
+	workerBehavior(world, colony, worker)
+	if colony.Haul != nil || colony.Stores != nil || worker.Hauling != 0 {
+		t.Error("A move called off should leave the stores beside the queen and the load back with them")
+	}
+}
+
+func TestOnlyAFewWorkersHaulTheStores(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	for i := 0; i < 4; i++ {
+		SpawnWorker(colony, 5+i, 1)
+	}
+	colony.Workers[0].CarryingFood = true
+	colony.Haul = &types.StoresHaul{To: nil, Moved: 0}
+
+	haulers := 0
+	for _, worker := range colony.Workers {
+		if isHauler(colony, worker) {
+			haulers++
+		}
+	}
+	if haulers != storeHaulers {
+		t.Errorf("Expected %d haulers, got %d", storeHaulers, haulers)
+	}
+	if isHauler(colony, colony.Workers[0]) {
+		t.Error("A worker carrying food should not be drafted to haul")
+	}
+}
@@ -0,0 +1,288 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+)
+
+// tasks.go - Adults taking up whatever job the colony needs doing
+// Every so often the colony measures how loudly each job is calling: larvae
+// nobody is caring for call for nurses, low stores for foragers, a cramped
+// nest or an undug plan for diggers and fresh sightings for guards. Stores
+// call for foragers by whichever diet is furthest below its reserve, so a
+// granary full of seed does not hide a colony out of protein. Each ant
+// has a threshold for each job, and answers the call that most exceeds its
+// threshold. Age moves the thresholds: the young are quickest to nurse, the
+// middle-aged to dig, and the old to forage and guard. A small personal bias
+// rolled at birth keeps nestmates from all switching at once. An ant that
+// changes job keeps its ID, age, health and everything else about it, and
+// only its role changes. A colony always keeps a few foragers, so a spell of
+// sightings cannot send every one of them off to guard.
+
+// Task allocation tuning
+var (
+	taskInterval   = 50 // Ticks between ants reconsidering their task
+	taskSwitches   = 2  // Most ants in a colony that change task at once
+	switchMargin   = 20 // How much louder a new task has to call than the current one
+	taskBiasSpread = 10 // Personal thresholds vary up to this much either side
+	larvaeCall     = 25 // Call to nurse for each larvae nobody is caring for
+	threatCall     = 25 // Call to guard for each fresh sighting
+	minForagers    = 2  // Foragers kept on however loudly other tasks call
+	roomPerAnt     = 30 // Open cells the colony wants per ant; less calls ants to dig
+)
+
+// taskThreshold is how loudly each task has to call before age and bias
+var taskThreshold = [types.NumTasks]int{
+	types.Nursing:  20,
+	types.Foraging: 30,
+	types.Digging:  30,
+	types.Guarding: 40,
+}
+
+// allocateTasks lets a few free adults change task to answer the colony's
+// needs. Runs every taskInterval ticks. The head nurse keeps her post, and
+// the last minForagers foragers stay out foraging
+func allocateTasks(world *types.World, colony *types.Colony) {
+	if world.Ticks%taskInterval != 0 {
+		return
+	}
+	calls := taskCalls(world, colony)
+
+	var free []types.AntInterface
+	for _, worker := range colony.Workers {
+		if !worker.CarryingFood && !worker.CarryingCorpse && worker.Hauling == 0 {
+			free = append(free, worker)
+		}
+	}
+	for _, nurse := range colony.Nurses {
+		if nurse.CurrentlyNursing == nil && nurse.FoodAmount == 0 {
+			free = append(free, nurse)
+		}
+	}
+	for _, soldier := range colony.Soldiers {
+		free = append(free, soldier)
+	}
+	world.Random.Shuffle(len(free), func(i, j int) { free[i], free[j] = free[j], free[i] })
+
+	foragers := countForagers(colony)
+	switched := 0
+	for _, ant := range free {
+		if switched >= taskSwitches {
+			return
+		}
+		if ant.GetAnt().Health <= 0 {
+			continue
+		}
+		task := bestTask(ant.GetAnt(), calls)
+		if task == ant.GetAnt().Task {
+			continue
+		}
+		if ant.GetAnt().Task == types.Foraging {
+			if foragers <= minForagers {
+				continue
+			}
+			foragers--
+		} else if task == types.Foraging {
+			foragers++
+		}
+		changeTask(world, colony, ant, task)
+		switched++
+	}
+}
+
+// countForagers counts the colony's adults out foraging
+func countForagers(colony *types.Colony) int {
+	count := 0
+	for _, worker := range colony.Workers {
+		if worker.Task == types.Foraging {
+			count++
+		}
+	}
+	return count
+}
+
+// taskCalls measures how loudly each task is calling, from 0 to 100. Digging
+// answers both a cramped nest and whatever of the nest plan is still soil
+func taskCalls(world *types.World, colony *types.Colony) [types.NumTasks]int {
+	var calls [types.NumTasks]int
+
+	for _, larvae := range colony.Larvae {
+		if !larvae.HasNurseCare {
+			calls[types.Nursing] += larvaeCall
+		}
+	}
+	calls[types.Foraging] = max(
+		shortfall(colony.Food.Available(adultDiet...), adultReserve),
+		shortfall(colony.Food.Available(broodDiet...), broodReserve),
+	)
+	if want := colony.GetAntCount() * roomPerAnt; want > 0 {
+		calls[types.Digging] = (want - nestSize(colony)) * 100 / want
+	}
+	calls[types.Digging] = max(calls[types.Digging], 0) + len(plannedDigs(world, colony))*planCall
+	for _, s := range colony.Sightings {
+		if world.Ticks-s.Tick < threatMemory {
+			calls[types.Guarding] += threatCall
+		}
+	}
+
+	for i := range calls {
+		calls[i] = max(0, min(100, calls[i]))
+	}
+	return calls
+}
+
+// shortfall is how far a store falls below its reserve, from 0 to 100
+func shortfall(stock, reserve int) int {
+	if stock >= reserve {
+		return 0
+	}
+	return (reserve - stock) * 100 / reserve
+}
+
+// threshold is how loudly a task has to call before an ant answers, given its
+// age and personal bias
+func threshold(ant *types.Ant, task types.Task) int {
+	life := ant.Age * 100 / max(1, ant.MaxAge) // 0 when newly hatched, 100 at the end
+	shift := 0
+	switch task {
+	case types.Nursing:
+		shift = life
+	case types.Digging:
+		shift = max(life-50, 50-life)
+	case types.Foraging, types.Guarding:
+		shift = (100 - life) / 2
+	}
+	return taskThreshold[task] + shift + ant.TaskBias[task]
+}
+
+// bestTask picks the task that calls most loudly past an ant's threshold. The
+// ant sticks with its current task unless another beats it by switchMargin.
+// Digging is only a stint, and a digger no longer called for goes back to
+// foraging
+func bestTask(ant *types.Ant, calls [types.NumTasks]int) types.Task {
+	best := ant.Task
+	bestResponse := calls[ant.Task] - threshold(ant, ant.Task) + switchMargin
+	if best == types.Digging && bestResponse <= switchMargin {
+		best = types.Foraging
+		bestResponse = calls[types.Foraging] - threshold(ant, types.Foraging)
+	}
+	for task := types.Nursing; task < types.NumTasks; task++ {
+		response := calls[task] - threshold(ant, task)
+		if response > 0 && response > bestResponse {
+			best = task
+			bestResponse = response
+		}
+	}
+	return best
+}
+
+// changeTask puts an ant on a new task, changing its role to suit
+// The ant keeps its base Ant, so its ID, age and history carry over, and
+// leaves its old role set aside so it picks up where it was if it goes back
+func changeTask(world *types.World, colony *types.Colony, ant types.AntInterface, task types.Task) {
+	base := ant.GetAnt()
+	base.Task = task
+	base.CurrentAction = "took up " + task.String()
+	if task.Role() == base.Role {
+		if worker, ok := ant.(*types.WorkerAnt); ok {
+			worker.TargetPosition = nil
+		}
+		return
+	}
+
+	base.Leave(ant)
+	switch a := ant.(type) {
+	case *types.WorkerAnt:
+		RemoveWorker(colony, a)
+	case *types.NurseAnt:
+		RemoveNurse(colony, a)
+	case *types.SoldierAnt:
+		RemoveSoldier(colony, a)
+	}
+
+	var next types.AntInterface
+	switch task.Role() {
+	case types.Nurse:
+		nurse := types.AsNurse(base)
+		colony.Nurses = append(colony.Nurses, nurse)
+		next = nurse
+	case types.Soldier:
+		soldier := types.AsSoldier(base)
+		colony.Soldiers = append(colony.Soldiers, soldier)
+		next = soldier
+	default:
+		worker := types.AsWorker(base)
+		colony.Workers = append(colony.Workers, worker)
+		next = worker
+	}
+
+	if cell := world.GetCell(base.Position.X, base.Position.Y); cell != nil && cell.Occupant == ant {
+		cell.Occupant = next
+	}
+}
+
+// rollTaskBias gives a newly matured adult its personal thresholds
+func rollTaskBias(world *types.World, ant *types.Ant) {
+	for task := types.Nursing; task < types.NumTasks; task++ {
+		ant.TaskBias[task] = int(world.Random.Range(int32(-taskBiasSpread), int32(taskBiasSpread+1)))
+	}
+}
+
+// nestSize counts the open cells below the surface within the patrol radius
+// that connect to the queen
+func nestSize(colony *types.Colony) int {
+	field := colony.HomeField
+	if field == nil {
+		return 0
+	}
+	queen := colony.QueenPosition
+	size := 0
+	for y := max(queen.Y-patrolRadius, surfaceRow+1); y <= queen.Y+patrolRadius; y++ {
+		for x := queen.X - patrolRadius; x <= queen.X+patrolRadius; x++ {
+			if field.At(x, y) != types.Unreachable {
+				size++
+			}
+		}
+	}
+	return size
+}
+
+// excavate has a digging worker extend the nest: it digs out the colony's
+// plan first, then into soil next to it within the patrol radius, or else
+// walks out to the edge of the nest to find some. Returns true if the worker
+// dug or moved
+func excavate(world *types.World, colony *types.Colony, worker *types.WorkerAnt) bool {
+	if digPlan(world, colony, worker) {
+		return true
+	}
+
+	var faces []types.Position
+	for _, dir := range pathfinder.GetAllDirections() {
+		dx, dy := pathfinder.DirectionToOffset(dir)
+		pos := types.Position{X: worker.Position.X + dx, Y: worker.Position.Y + dy}
+		if pos.Y > surfaceRow+1 && withinPatrol(colony, pos) && pathfinder.CanDigTo(world, pos.X, pos.Y) {
+			faces = append(faces, pos)
+		}
+	}
+	if len(faces) > 0 {
+		face := faces[world.Random.Below(uint32(len(faces)))]
+		worker.TargetPosition = nil
+		worker.CurrentAction = "digging out the nest"
+		return pathfinder.DigAndMove(world, worker, face.X, face.Y)
+	}
+
+	if worker.TargetPosition == nil || pathfinder.IsAdjacentOrSame(worker.Position, *worker.TargetPosition) {
+		waypoints := patrolWaypoints(world, colony)
+		if len(waypoints) == 0 {
+			return false
+		}
+		edge := waypoints[world.Random.Below(uint32(len(waypoints)))]
+		worker.TargetPosition = &edge
+	}
+	worker.CurrentAction = "heading out to dig"
+	if !pathfinder.MoverFor(colony, types.Worker).Toward(world, colony, worker, *worker.TargetPosition) {
+		worker.TargetPosition = nil
+		return false
+	}
+	return true
+}
@@ -0,0 +1,259 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestThresholdsShiftWithAge(t *testing.T) {
+	ant := types.NewAnt(1, types.Worker, 0, 0, "Red", 100, 500)
+
+	if threshold(ant, types.Nursing) >= threshold(ant, types.Foraging) {
+		t.Error("A young ant should be quicker to nurse than to forage")
+	}
+	ant.Age = 450
+	if threshold(ant, types.Foraging) >= threshold(ant, types.Nursing) {
+		t.Error("An old ant should be quicker to forage than to nurse")
+	}
+	ant.TaskBias[types.Foraging] = 7
+	if got, want := threshold(ant, types.Foraging), taskThreshold[types.Foraging]+5+7; got != want {
+		t.Errorf("Expected threshold %d with bias, got %d", want, got)
+	}
+}
+
+func TestYoungWorkerAnswersUntendedLarvae(t *testing.T) {
+	ant := types.NewAnt(1, types.Worker, 0, 0, "Red", 100, 500)
+	var calls [types.NumTasks]int
+	calls[types.Nursing] = 50
+
+	if got := bestTask(ant, calls); got != types.Nursing {
+		t.Errorf("Expected a young worker to take up nursing, got %s", got)
+	}
+
+	calls[types.Nursing] = 0
+	if got := bestTask(ant, calls); got != types.Foraging {
+		t.Errorf("With nothing calling, a worker should keep foraging, got %s", got)
+	}
+}
+
+func TestAntSticksWithTaskUnlessClearlyBetter(t *testing.T) {
+	ant := types.NewAnt(1, types.Soldier, 0, 0, "Red", 150, 600)
+	ant.Age = 300
+	var calls [types.NumTasks]int
+	calls[types.Guarding] = 50
+	calls[types.Foraging] = 60 // Only a little louder past the thresholds
+
+	if got := bestTask(ant, calls); got != types.Guarding {
+		t.Errorf("Expected the soldier to keep guarding, got %s", got)
+	}
+}
+
+func TestDiggerGoesBackToForaging(t *testing.T) {
+	ant := types.NewAnt(1, types.Worker, 0, 0, "Red", 100, 500)
+	ant.Age = 250
+	ant.Task = types.Digging
+	var calls [types.NumTasks]int
+
+	if got := bestTask(ant, calls); got != types.Foraging {
+		t.Errorf("A digger no longer called for should go back to foraging, got %s", got)
+	}
+	calls[types.Digging] = 60
+	if got := bestTask(ant, calls); got != types.Digging {
+		t.Errorf("A digger still called for should keep digging, got %s", got)
+	}
+}
+
+func TestChangeTaskKeepsTheAnt(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	worker := colony.Workers[0]
+	worker.Age = 120
+	worker.Hunger = 40
+	base := worker.Ant
+
+	changeTask(world, colony, worker, types.Nursing)
+
+	if len(colony.Workers) != 0 || len(colony.Nurses) != 1 {
+		t.Fatalf("Expected the worker to move to the nurses, got %d workers and %d nurses",
+			len(colony.Workers), len(colony.Nurses))
+	}
+	nurse := colony.Nurses[0]
+	if nurse.Ant != base || nurse.ID != base.ID || nurse.Age != 120 || nurse.Hunger != 40 {
+		t.Error("The nurse should be the same ant with the same history")
+	}
+	if nurse.Role != types.Nurse || nurse.Task != types.Nursing {
+		t.Errorf("Expected a nurse on nursing, got %s on %s", nurse.Role, nurse.Task)
+	}
+	if world.GetCell(nurse.Position.X, nurse.Position.Y).Occupant != nurse {
+		t.Error("The cell should hold the ant in its new role")
+	}
+}
+
+func TestChangeTaskBackKeepsRoleHistory(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	worker := colony.Workers[0]
+	worker.Deposits = 7
+	worker.HomeVector = types.Position{X: 2, Y: -1}
+	worker.RememberFood(types.Position{X: 5, Y: 1}, 300)
+
+	changeTask(world, colony, worker, types.Nursing)
+	nurse := colony.Nurses[0]
+	nurse.LarvaeNursed = 3
+	changeTask(world, colony, nurse, types.Foraging)
+	back := colony.Workers[0]
+
+	if back.Deposits != 7 || back.HomeVector != (types.Position{X: 2, Y: -1}) || len(back.FoodSites) != 1 {
+		t.Errorf("The worker should come back with its deliveries, home vector and food sites, got %d, %v, %v",
+			back.Deposits, back.HomeVector, back.FoodSites)
+	}
+
+	changeTask(world, colony, back, types.Nursing)
+	if again := colony.Nurses[0]; again.LarvaeNursed != 3 {
+		t.Errorf("The nurse should come back with its count of larvae raised, got %d", again.LarvaeNursed)
+	}
+}
+
+func TestChangeTaskWithinRole(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	worker := colony.Workers[0]
+
+	changeTask(world, colony, worker, types.Digging)
+
+	if len(colony.Workers) != 1 || colony.Workers[0] != worker || worker.Task != types.Digging {
+		t.Error("Switching between worker tasks should keep the same worker")
+	}
+}
+
+func TestAllocateTasksSkipsBusyAnts(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	colony.Workers[0].CarryingFood = true
+	for i := 0; i < 4; i++ {
+		SpawnLarvae(colony, 5+i, 5)
+	}
+
+	allocateTasks(world, colony)
+
+	if len(colony.Workers) != 1 || colony.Workers[0].Task != types.Foraging {
+		t.Error("A worker carrying food should not change task")
+	}
+}
+
+func TestAllocateTasksLimitsSwitches(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	for i := 0; i < 6; i++ {
+		worker := SpawnWorker(colony, 5+i, 1)
+		PlaceAnt(world, worker)
+	}
+	for i := 0; i < 4; i++ {
+		SpawnLarvae(colony, 5+i, 5)
+	}
+
+	allocateTasks(world, colony)
+
+	if len(colony.Nurses) != taskSwitches {
+		t.Errorf("Expected %d workers to take up nursing, got %d", taskSwitches, len(colony.Nurses))
+	}
+}
+
+func TestTaskCalls(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	colony.Food = types.FoodStore{types.Carbohydrate: adultReserve / 4, types.Protein: broodReserve}
+	SpawnLarvae(colony, 5, 5)
+	cared := SpawnLarvae(colony, 6, 5)
+	cared.HasNurseCare = true
+	colony.ReportThreat(types.Position{X: 25, Y: 1}, world.Ticks)
+
+	calls := taskCalls(world, colony)
+	if calls[types.Nursing] != larvaeCall {
+		t.Errorf("Expected nursing call %d, got %d", larvaeCall, calls[types.Nursing])
+	}
+	if calls[types.Foraging] != 75 {
+		t.Errorf("Expected foraging call 75 with stores a quarter full, got %d", calls[types.Foraging])
+	}
+	if calls[types.Guarding] != threatCall {
+		t.Errorf("Expected guarding call %d, got %d", threatCall, calls[types.Guarding])
+	}
+	if calls[types.Digging] <= 0 {
+		t.Errorf("A newly founded nest should call for diggers, got %d", calls[types.Digging])
+	}
+}
+
+func TestForagingCallFollowsShortestStore(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	colony.Food = types.FoodStore{types.Carbohydrate: adultReserve * 4, types.Protein: broodReserve / 2}
+
+	if got := taskCalls(world, colony)[types.Foraging]; got != 50 {
+		t.Errorf("Expected foraging call 50 with protein half its reserve, got %d", got)
+	}
+
+	colony.Food[types.Protein] = broodReserve
+	if got := taskCalls(world, colony)[types.Foraging]; got != 0 {
+		t.Errorf("Expected no foraging call with both stores full, got %d", got)
+	}
+}
+
+func TestAllocateTasksKeepsSomeForagers(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	colony.Food = types.FoodStore{types.Carbohydrate: adultReserve, types.Protein: broodReserve}
+	for i := 0; i < 5; i++ {
+		worker := SpawnWorker(colony, 5+i, 1)
+		worker.Age = worker.MaxAge * 9 / 10
+		PlaceAnt(world, worker)
+	}
+	for i := 0; i < types.MaxSightings; i++ {
+		colony.ReportThreat(types.Position{X: 5 * i, Y: 1}, world.Ticks)
+	}
+
+	for i := 0; i < 5; i++ {
+		allocateTasks(world, colony)
+	}
+
+	if len(colony.Soldiers) == 0 {
+		t.Error("Expected some old workers to take up guarding")
+	}
+	if got := countForagers(colony); got != minForagers {
+		t.Errorf("Expected %d foragers kept on through the sightings, got %d", minForagers, got)
+	}
+}
+
+func TestExcavateDigsIntoSoil(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+	worker := colony.Workers[0]
+	tunnels := 0
+	for i := range world.Cells {
+		if world.Cells[i].IsTunnel {
+			tunnels++
+		}
+	}
+
+	if !excavate(world, colony, worker) {
+		t.Fatal("Worker next to soil should dig")
+	}
+	after := 0
+	for i := range world.Cells {
+		if world.Cells[i].IsTunnel {
+			after++
+		}
+	}
+	if after != tunnels+1 {
+		t.Errorf("Expected one new tunnel cell, got %d", after-tunnels)
+	}
+}
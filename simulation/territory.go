@@ -0,0 +1,114 @@
+package logic
+
+import (
+	"antfarm/pathfinder"
+	"antfarm/types"
+	"fmt"
+)
+
+// territory.go - Territorial marks and the borders between colonies
+// Every adult ant marks the cell it stands on with its colony's territory
+// pheromone, so the tunnels and surface a colony actually uses carry its
+// smell. Every so often the world works out who holds each cell: whoever has
+// the strongest mark there, as long as it is strong enough to count. Workers
+// that wander onto ground another colony holds back out of it, and soldiers
+// go looking for the ants that hold it.
+
+// Territory tuning
+var (
+	territoryDeposit    = 20 // Mark an adult leaves on its cell each tick
+	territoryClaimLevel = 50 // Weakest mark that holds a cell
+	territoryInterval   = 10 // Ticks between working out the territory map
+	contestMarkFactor   = 3  // How much harder a soldier marks ground another colony holds
+	challengeRange      = 5  // How far a soldier on foreign ground will go after an ant that holds it
+)
+
+// markTerritory has an ant mark the cell it is standing in as its colony's ground
+func markTerritory(world *types.World, ant *types.Ant) {
+	layPheromone(world, ant, types.Territory, territoryDeposit)
+}
+
+// updateTerritory works out which colony holds each cell and how much each holds
+// Ties go to the colony founded first, so the map is the same every run
+func updateTerritory(world *types.World) {
+	if world.Ticks%territoryInterval != 0 {
+		return
+	}
+
+	best := make([]int, len(world.Territory))
+	holders := make([]*types.Colony, len(world.Territory))
+	for _, colony := range world.Colonies {
+		colony.Territory = 0
+		levels := world.Pheromones.Levels(colony.Name, types.Territory)
+		for i, level := range levels {
+			if level >= territoryClaimLevel && level > best[i] {
+				best[i] = level
+				holders[i] = colony
+			}
+		}
+	}
+	for i, holder := range holders {
+		world.Territory[i] = ""
+		if holder != nil {
+			world.Territory[i] = holder.Name
+			holder.Territory++
+		}
+	}
+}
+
+// foreignTerritory returns the colony holding the ground an ant stands on if
+// it is not the ant's own. Returns nil on unclaimed or home ground
+func foreignTerritory(world *types.World, ant *types.Ant) *types.Colony {
+	owner := world.TerritoryOwner(ant.Position.X, ant.Position.Y)
+	if owner == nil || owner.Name == ant.ColonyID {
+		return nil
+	}
+	return owner
+}
+
+// avoidForeignTerritory backs an unladen worker out of ground another colony
+// holds, heading home. Returns false if the worker is not on foreign ground
+func avoidForeignTerritory(world *types.World, colony *types.Colony, worker *types.WorkerAnt) bool {
+	owner := foreignTerritory(world, worker.Ant)
+	if owner == nil {
+		return false
+	}
+
+	worker.CurrentAction = fmt.Sprintf("backing out of %s territory", owner.Name)
+	pathfinder.StepToward(world, worker, colony.QueenPosition)
+	return true
+}
+
+// contestTerritory has a soldier on ground another colony holds go after the
+// nearest ant of that colony, or mark the ground over as its own if there is
+// nobody to challenge. Returns false if the soldier is not on foreign ground
+func contestTerritory(world *types.World, soldier *types.SoldierAnt) bool {
+	owner := foreignTerritory(world, soldier.Ant)
+	if owner == nil {
+		return false
+	}
+
+	var intruder *types.Ant
+	minDist := challengeRange + 1
+	for _, ant := range owner.GetAllAnts() {
+		other := ant.GetAnt()
+		if dist := pathfinder.ManhattanDistance(soldier.Position, other.Position); dist < minDist {
+			minDist = dist
+			intruder = other
+		}
+	}
+
+	if intruder == nil {
+		soldier.CurrentAction = fmt.Sprintf("contesting %s territory", owner.Name)
+		layPheromone(world, soldier.Ant, types.Territory, territoryDeposit*contestMarkFactor)
+		return true
+	}
+	if pathfinder.IsAdjacent(soldier.Position, intruder.Position) {
+		soldier.CurrentAction = fmt.Sprintf("facing down %s ant", owner.Name)
+		return true
+	}
+
+	soldier.CurrentAction = fmt.Sprintf("challenging %s ant", owner.Name)
+	pathfinder.StepToward(world, soldier, intruder.Position)
+	return true
+}
@@ -0,0 +1,99 @@
+package logic
+
+import (
+	"antfarm/random"
+	"antfarm/types"
+	"testing"
+)
+
+func TestAntsMarkTerritory(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	worker := types.NewWorker(1, 10, 1, "Red")
+	world.GetCell(10, 1).Occupant = worker
+
+	markTerritory(world, worker.Ant)
+
+	if got := world.Pheromones.Level("Red", types.Territory, 10, 1); got != territoryDeposit {
+		t.Errorf("Expected mark %d where the worker stands, got %d", territoryDeposit, got)
+	}
+}
+
+func TestUpdateTerritoryStrongestMarkHolds(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	red := types.NewColony("Red", 10, 15, types.ColonyRed)
+	blue := types.NewColony("Blue", 30, 15, types.ColonyBlue)
+	AddColony(world, red)
+	AddColony(world, blue)
+	world.Pheromones.Deposit("Red", types.Territory, 5, 1, 200)
+	world.Pheromones.Deposit("Blue", types.Territory, 5, 1, 100)
+	world.Pheromones.Deposit("Blue", types.Territory, 6, 1, 100)
+	world.Pheromones.Deposit("Blue", types.Territory, 7, 1, territoryClaimLevel-1)
+	world.Ticks = territoryInterval
+
+	updateTerritory(world)
+
+	if owner := world.TerritoryOwner(5, 1); owner != red {
+		t.Error("The stronger mark should hold a contested cell")
+	}
+	if owner := world.TerritoryOwner(6, 1); owner != blue {
+		t.Error("Blue should hold the cell only it marked")
+	}
+	if owner := world.TerritoryOwner(7, 1); owner != nil {
+		t.Error("A mark below the claim level should not hold a cell")
+	}
+	if red.Territory != 1 || blue.Territory != 1 {
+		t.Errorf("Expected 1 cell each, got Red %d, Blue %d", red.Territory, blue.Territory)
+	}
+}
+
+func TestWorkerBacksOutOfForeignTerritory(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	red := types.NewColony("Red", 5, 1, types.ColonyRed)
+	blue := types.NewColony("Blue", 30, 15, types.ColonyBlue)
+	AddColony(world, red)
+	AddColony(world, blue)
+	worker := types.NewWorker(1, 15, 1, "Red")
+	world.GetCell(15, 1).Occupant = worker
+	world.Pheromones.Deposit("Blue", types.Territory, 15, 1, 200)
+	world.Ticks = territoryInterval
+	updateTerritory(world)
+
+	if !avoidForeignTerritory(world, red, worker) {
+		t.Fatal("Worker should back out of Blue territory")
+	}
+	if worker.Position.X >= 15 {
+		t.Errorf("Worker should head home, got (%d,%d)", worker.Position.X, worker.Position.Y)
+	}
+	if avoidForeignTerritory(world, red, worker) {
+		t.Error("Worker off Blue ground should carry on as normal")
+	}
+}
+
+func TestSoldierChallengesTerritoryHolder(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	red := types.NewColony("Red", 5, 15, types.ColonyRed)
+	blue := types.NewColony("Blue", 30, 15, types.ColonyBlue)
+	AddColony(world, red)
+	AddColony(world, blue)
+	soldier := SpawnSoldier(red, 15, 1)
+	world.GetCell(15, 1).Occupant = soldier
+	world.Pheromones.Deposit("Blue", types.Territory, 15, 1, 200)
+	world.Ticks = territoryInterval
+	updateTerritory(world)
+
+	// Nobody to challenge, so the soldier marks the ground over
+	if !contestTerritory(world, soldier) {
+		t.Fatal("Soldier on Blue ground should contest it")
+	}
+	if got := world.Pheromones.Level("Red", types.Territory, 15, 1); got != territoryDeposit*contestMarkFactor {
+		t.Errorf("Expected counter-mark %d, got %d", territoryDeposit*contestMarkFactor, got)
+	}
+
+	// A Blue ant nearby gets challenged
+	blueWorker := SpawnWorker(blue, 18, 1)
+	world.GetCell(18, 1).Occupant = blueWorker
+	contestTerritory(world, soldier)
+	if soldier.Position.X != 16 {
+		t.Errorf("Soldier should step toward the Blue ant, got (%d,%d)", soldier.Position.X, soldier.Position.Y)
+	}
+}
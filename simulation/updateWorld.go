@@ -1,8 +1,8 @@
 package logic
 
 import (
+	"antfarm/pathfinder"
 	"antfarm/types"
-	"antfarm/util"
 )
 
 // updateWorld.go - Main World simulation update logic
@@ -13,20 +13,56 @@ import (
 func UpdateWorld(world *types.World) {
 	world.Ticks++
 
-	// Update each colony's ants and resources
+	// Aphids feed and wander, spiders hunt them
+	updateCritters(world)
+
+	// Insects die on the surface for foragers to bring home
+	dropPrey(world)
+
+	// Rain soaks in and water runs down through the tunnels
+	updateMoisture(world)
+
+	// Food left lying out rots away
+	rotFood(world)
+
+	// Bodies age, fester underground and dry up on the surface
+	updateCorpses(world)
+
+	// Pathogens spread between neighbours, then take hold or are fought off
+	updateDisease(world)
+
+	// Pheromones fade and spread
+	updatePheromones(world)
+
+	// Work out who holds which ground from the territory marks
+	updateTerritory(world)
+
+	// Food scent spreads out from whatever pellets are left
+	spreadScent(world)
+
+	// Update each colony's ants and resources. Every ant declares its move,
+	// then the moves are settled together so list order wins nobody a cell
+	pathfinder.BeginMoves(world)
 	for _, colony := range world.Colonies {
 		updateColony(world, colony)
 	}
+	pathfinder.CommitMoves(world)
 }
 
 // Timing constants
 var (
 	eggLayingInterval = 50                   // Queen lays eggs every 50 ticks
 	eggHatchTime      = 30                   // Eggs become larvae after 30 ticks
-	larvaeGrowTime    = 50                   // Larvae become workers after 50 ticks with nurse care
-	foodCost          = 1                    // Cost per egg, in food units (0.1 food)
+	foodCost          = 1                    // Cost per egg, in protein units (0.1 food)
 	layingThreshold   = 10 * types.FoodScale // Queen needs 10 food in store to lay
 
+	// Larvae only grow when a nurse feeds them. Each feeding costs one ration
+	// from the stores and adds larvaeGrowthPerFeeding times the nurse's
+	// NursingSpeed, so a speed-1 nurse raises a larvae in five feedings.
+	larvaeMealCost         = 1  // Food units per feeding (0.1 food)
+	larvaeGrowthPerFeeding = 20 // Growth progress per feeding at NursingSpeed 1
+	larvaeFeedingHunger    = 10 // Hunger a larvae needs before it takes another feeding
+
 	// A queen does not age and is immortal until she bears an heir. That birth
 	// starts a slow decline: from then on she loses one health every this many
 	// ticks. Starting at 200 health that is a 6000 tick twilight, roughly 1.7
@@ -36,6 +72,12 @@ var (
 
 // updateColony handles all updates for a single colony
 func updateColony(world *types.World, colony *types.Colony) {
+	// Keep the home field measured from wherever the queen is now
+	trackQueen(world, colony)
+
+	// Keep the nest plan laid out round her and up to date with the digging
+	planNest(world, colony)
+
 	// Set default queen action
 	if colony.Queen != nil {
 		colony.Queen.CurrentAction = "resting"
@@ -50,17 +92,28 @@ func updateColony(world *types.World, colony *types.Colony) {
 		colony.Queen.CurrentAction = "fading"
 	}
 
+	// The queen is fed from the stores wherever they are kept
+	if colony.Queen != nil {
+		feedQueen(world, colony)
+	}
+
 	// Process deaths first (health <= 0 or old age)
 	processDeaths(world, colony)
 
+	// The fungus garden grows, or dies if it has been neglected or flooded
+	updateGarden(world, colony)
+
+	// Stored food slowly spoils, faster if the store is damp
+	spoilStore(world, colony)
+
 	// The queen lays a single egg periodically. A queenless colony lays nothing:
 	// it lives on whatever brood and workers it already has.
 	if colony.Queen != nil && world.Ticks > 0 && world.Ticks%eggLayingInterval == 0 &&
-		colony.Food >= layingThreshold {
-		// One egg per laying event, paid for up front
-		if colony.Food >= foodCost {
+		colony.Food.Total() >= layingThreshold {
+		// One egg per laying event, paid for up front out of the brood diet
+		if colony.Food.Available(broodDiet...) >= foodCost {
 			colony.Eggs++
-			colony.Food -= foodCost
+			colony.Food.Spend(foodCost, broodDiet...)
 			colony.Queen.TotalEggsLaid++
 		}
 
@@ -71,51 +124,46 @@ func updateColony(world *types.World, colony *types.Colony) {
 	if world.Ticks > 0 && world.Ticks%eggHatchTime == 0 && colony.Eggs > 0 {
 		colony.Eggs--
 
-		// Find an empty cell near queen to spawn larvae
-		spawnX, spawnY := findEmptySpawnPosition(world, colony.QueenPosition)
+		// Find an empty cell in the nursery, or near the queen, to spawn larvae
+		spawnX, spawnY := hatchPosition(world, colony)
 		if spawnX != -1 && spawnY != -1 {
 			larvae := SpawnLarvae(colony, spawnX, spawnY)
 
 			// Place larvae in world
+			world.Dig(spawnX, spawnY)
 			cell := world.GetCell(spawnX, spawnY)
 			if cell != nil {
-				cell.IsTunnel = true
 				cell.Occupant = larvae
 			}
 		}
 	}
 
-	// Age larvae first
+	// Age larvae first. They get hungry like adults but can only be fed by a nurse
 	for _, larvae := range colony.Larvae {
 		larvae.Age++
+		metabolize(world, larvae.Ant)
 	}
 
-	// Check larvae that have been nursed - they become workers
+	// Check larvae that have been fed to full growth - they become adults
 	for i := len(colony.Larvae) - 1; i >= 0; i-- {
 		larvae := colony.Larvae[i]
 
-		if larvae.HasNurseCare && larvae.Age >= larvaeGrowTime {
+		if larvae.GrowthProgress >= types.LarvaeMatureProgress {
 			// Remove larvae from world
 			RemoveAnt(world, larvae)
 
 			// Determine what role this larvae becomes
 			// Rolls 1-100, then checks thresholds
 			newAnt := matureLarvaeToAnt(colony, larvae, int(world.Random.Below(100)))
+			rollTaskBias(world, newAnt.GetAnt())
 
 			// Place worker in world
 			PlaceAnt(world, newAnt)
 
-			// Clear the nurse's CurrentlyNursing if it was this larvae
-			if colony.HeadNurse != nil && colony.HeadNurse.CurrentlyNursing != nil &&
-				colony.HeadNurse.CurrentlyNursing.ID == larvae.ID {
-				colony.HeadNurse.LarvaeNursed++
-				colony.HeadNurse.CurrentlyNursing = nil
-			}
-			for _, nurse := range colony.Nurses {
-				if nurse.CurrentlyNursing != nil && nurse.CurrentlyNursing.ID == larvae.ID {
-					nurse.LarvaeNursed++
-					nurse.CurrentlyNursing = nil
-				}
+			// Credit the nurse who raised it and free her for the next one
+			if nurse := nurseOf(colony, larvae); nurse != nil {
+				nurse.LarvaeNursed++
+				nurse.CurrentlyNursing = nil
 			}
 
 			// Remove from larvae list
@@ -123,6 +171,12 @@ func updateColony(world *types.World, colony *types.Colony) {
 		}
 	}
 
+	// A few adults change task to whatever the colony needs most
+	allocateTasks(world, colony)
+
+	// The head nurse hands out larvae that need care before anyone moves
+	coordinateNursing(colony)
+
 	// Update head nurse
 	if colony.HeadNurse != nil {
 		updateNurse(world, colony, colony.HeadNurse)
@@ -140,40 +194,19 @@ func updateColony(world *types.World, colony *types.Colony) {
 
 	// Update soldiers
 	for _, soldier := range colony.Soldiers {
-		updateSoldier(world, soldier)
+		updateSoldier(world, colony, soldier)
 	}
 
 	// Set larvae action based on whether a nurse is actively caring for them
 	for _, larvae := range colony.Larvae {
 
 		// Check if this specific larvae is being nursed (nurse is adjacent)
-		isBeingNursed := false
-
-		if colony.HeadNurse != nil && colony.HeadNurse.CurrentlyNursing != nil &&
-			colony.HeadNurse.CurrentlyNursing.ID == larvae.ID {
-			// Check nurse is actually adjacent
-			xDist := util.Abs(colony.HeadNurse.Position.X - larvae.Position.X)
-			yDist := util.Abs(colony.HeadNurse.Position.Y - larvae.Position.Y)
-			if xDist <= 1 && yDist <= 1 {
-				isBeingNursed = true
-			}
-		}
+		nurse := nurseOf(colony, larvae)
+		isBeingNursed := nurse != nil && pathfinder.IsAdjacentOrSame(nurse.Position, larvae.Position)
 
-		// Also check all other nurses
-		if !isBeingNursed {
-			for _, nurse := range colony.Nurses {
-				if nurse.CurrentlyNursing != nil && nurse.CurrentlyNursing.ID == larvae.ID {
-					xDist := util.Abs(nurse.Position.X - larvae.Position.X)
-					yDist := util.Abs(nurse.Position.Y - larvae.Position.Y)
-					if xDist <= 1 && yDist <= 1 {
-						isBeingNursed = true
-						break
-					}
-				}
-			}
-		}
-
-		if isBeingNursed {
+		if larvae.Starving {
+			larvae.CurrentAction = "starving"
+		} else if isBeingNursed {
 			larvae.CurrentAction = "getting care"
 		} else {
 			larvae.CurrentAction = "waiting for care"
@@ -197,7 +230,7 @@ func findEmptySpawnPosition(world *types.World, queenPos types.Position) (int, i
 		y := queenPos.Y + offset[1]
 
 		cell := world.GetCell(x, y)
-		if cell != nil && cell.Occupant == nil {
+		if cell != nil && cell.Occupant == nil && cell.Critter == nil && cell.Corpse == nil {
 			return x, y
 		}
 	}
@@ -206,13 +239,16 @@ func findEmptySpawnPosition(world *types.World, queenPos types.Position) (int, i
 }
 
 // processDeaths checks all ants for death conditions and removes dead ants
-// Ants die from: health <= 0 (exhaustion/damage) or age >= maxAge (old age)
+// Ants die from: health <= 0 (exhaustion/damage/starvation) or age >= maxAge (old age)
+// Each death is tallied on the colony under its cause
 func processDeaths(world *types.World, colony *types.Colony) {
 	// Check queen death, then hand the throne to the longest-waiting heir.
 	// She is crowned where she stands, so the colony's centre moves with her.
 	// With no heir the colony is queenless and lays no more eggs.
 	if colony.Queen != nil && colony.Queen.IsDead() {
+		colony.RecordDeath(colony.Queen.CauseOfDeath())
 		RemoveAnt(world, colony.Queen)
+		leaveCorpse(world, colony.Queen.Ant)
 		colony.Queen = nil
 
 		if len(colony.Queens) > 0 {
@@ -220,6 +256,7 @@ func processDeaths(world *types.World, colony *types.Colony) {
 			colony.Queens = colony.Queens[1:]
 			colony.Queen = heir
 			colony.QueenPosition = heir.Position
+			trackQueen(world, colony)
 			heir.CurrentAction = "took the throne"
 
 			// A colony keeps exactly one queen, so every heir who was not
@@ -233,30 +270,42 @@ func processDeaths(world *types.World, colony *types.Colony) {
 
 	// Check head nurse death
 	if colony.HeadNurse != nil && colony.HeadNurse.IsDead() {
+		colony.RecordDeath(colony.HeadNurse.CauseOfDeath())
+		if colony.HeadNurse.CurrentlyNursing != nil {
+			colony.HeadNurse.CurrentlyNursing.HasNurseCare = false
+		}
 		RemoveAnt(world, colony.HeadNurse)
+		leaveCorpse(world, colony.HeadNurse.Ant)
 		colony.HeadNurse = nil
-		// TODO: Promote a nurse to head nurse w new Queen
 	}
 
 	// Check other nurses - iterate backwards for safe removal
 	for i := len(colony.Nurses) - 1; i >= 0; i-- {
 		nurse := colony.Nurses[i]
 		if nurse.IsDead() {
+			colony.RecordDeath(nurse.CauseOfDeath())
 			// If this nurse was caring for a larvae, mark it as needing care again
 			if nurse.CurrentlyNursing != nil {
 				nurse.CurrentlyNursing.HasNurseCare = false
 			}
 			RemoveAnt(world, nurse)
+			leaveCorpse(world, nurse.Ant)
 			RemoveNurse(colony, nurse)
 		}
 	}
 
+	// A colony that has lost its head nurse promotes the most experienced
+	// nurse still standing
+	promoteHeadNurse(world, colony)
+
 	// Check workers - iterate backwards for safe removal
 	for i := len(colony.Workers) - 1; i >= 0; i-- {
 		worker := colony.Workers[i]
 		if worker.IsDead() {
+			colony.RecordDeath(worker.CauseOfDeath())
 			// If worker was carrying food, it's lost
 			RemoveAnt(world, worker)
+			leaveCorpse(world, worker.Ant)
 			RemoveWorker(colony, worker)
 		}
 	}
@@ -265,7 +314,9 @@ func processDeaths(world *types.World, colony *types.Colony) {
 	for i := len(colony.Soldiers) - 1; i >= 0; i-- {
 		soldier := colony.Soldiers[i]
 		if soldier.IsDead() {
+			colony.RecordDeath(soldier.CauseOfDeath())
 			RemoveAnt(world, soldier)
+			leaveCorpse(world, soldier.Ant)
 			RemoveSoldier(colony, soldier)
 		}
 	}
@@ -274,18 +325,32 @@ func processDeaths(world *types.World, colony *types.Colony) {
 	for i := len(colony.Larvae) - 1; i >= 0; i-- {
 		larvae := colony.Larvae[i]
 		if larvae.IsDead() {
+			colony.RecordDeath(larvae.CauseOfDeath())
 			// Clear any nurse that was targeting this larvae
-			if colony.HeadNurse != nil && colony.HeadNurse.CurrentlyNursing != nil &&
-				colony.HeadNurse.CurrentlyNursing.ID == larvae.ID {
-				colony.HeadNurse.CurrentlyNursing = nil
-			}
-			for _, nurse := range colony.Nurses {
-				if nurse.CurrentlyNursing != nil && nurse.CurrentlyNursing.ID == larvae.ID {
-					nurse.CurrentlyNursing = nil
-				}
+			if nurse := nurseOf(colony, larvae); nurse != nil {
+				nurse.CurrentlyNursing = nil
 			}
 			RemoveAnt(world, larvae)
+			// Larvae a soldier has eaten leave nothing behind
+			if !larvae.Slain {
+				leaveCorpse(world, larvae.Ant)
+			}
 			RemoveLarvae(colony, larvae)
 		}
 	}
 }
+
+// trackQueen measures the colony's home field from the queen's chamber,
+// building the field the first time and rebuilding it whenever she has moved.
+// A queen swapped out of her cell mid-tick only moves when the tick's moves
+// are made, so her chamber is caught up with her here
+func trackQueen(world *types.World, colony *types.Colony) {
+	if colony.Queen != nil {
+		colony.QueenPosition = colony.Queen.Position
+	}
+	if colony.HomeField == nil {
+		colony.HomeField = types.NewDistanceField(world, colony.QueenPosition)
+		return
+	}
+	colony.HomeField.Track(world, colony.QueenPosition)
+}
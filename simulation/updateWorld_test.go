@@ -32,10 +32,28 @@ func TestUpdateWorldMultipleTicks(t *testing.T) {
 	}
 }
 
+func TestUpdateWorldMakesEveryMove(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	AddColony(world, colony)
+
+	for i := 0; i < 50; i++ {
+		UpdateWorld(world)
+		if world.Traffic.Pending || len(world.Traffic.Intents) != 0 {
+			t.Fatalf("Tick %d left moves unsettled", world.Ticks)
+		}
+		for _, worker := range colony.Workers {
+			if world.GetCell(worker.Position.X, worker.Position.Y).Occupant != worker {
+				t.Fatalf("Tick %d: worker %d is not in its cell", world.Ticks, worker.ID)
+			}
+		}
+	}
+}
+
 func TestQueenLaysEggs(t *testing.T) {
 	world := types.NewWorld(40, 30, random.New(1))
 	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
-	colony.Food = 200
+	colony.Food = types.FoodStore{types.Carbohydrate: 150, types.Protein: 50}
 	AddColony(world, colony)
 
 	// Run until egg laying tick // TODO make it a parameter or part of config
@@ -51,7 +69,7 @@ func TestQueenLaysEggs(t *testing.T) {
 func TestQueenDoesNotLayEggsWithoutFood(t *testing.T) {
 	world := types.NewWorld(40, 30, random.New(1))
 	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
-	colony.Food = 5 // Not enough
+	colony.Food = types.FoodStore{types.Protein: 5} // Not enough
 	AddColony(world, colony)
 
 	for i := 0; i < 50; i++ {
@@ -86,10 +104,10 @@ func TestLarvaeBecomesWorker(t *testing.T) {
 	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
 	AddColony(world, colony)
 
-	// Spawn larvae with nurse care
+	// Spawn larvae that has been fed to full growth
 	larvae := SpawnLarvae(colony, 21, 15)
 	larvae.HasNurseCare = true
-	larvae.Age = 49
+	larvae.GrowthProgress = types.LarvaeMatureProgress
 	PlaceAnt(world, larvae)
 
 	initialWorkers := len(colony.Workers)
@@ -123,7 +141,7 @@ func TestSameSeedProducesSameColony(t *testing.T) {
 			UpdateWorld(world)
 		}
 		return len(colony.Workers), len(colony.Nurses), len(colony.Soldiers),
-			len(colony.Larvae), colony.Food
+			len(colony.Larvae), colony.Food.Total()
 	}
 
 	w1, n1, s1, l1, f1 := run(777)
@@ -134,3 +152,95 @@ func TestSameSeedProducesSameColony(t *testing.T) {
 			w1, n1, s1, l1, f1, w2, n2, s2, l2, f2)
 	}
 }
+
+// TestLoneColonyStillLaysLate guards the protein supply: a colony with no
+// rivals to kill has only the prey it finds, and must still be raising brood
+// long after the protein it started with is gone.
+func TestLoneColonyStillLaysLate(t *testing.T) {
+	world := types.NewWorld(160, 45, random.New(42))
+	colony := types.NewColony("Red", 40, 16, types.ColonyRed)
+	AddColony(world, colony)
+
+	laid := 0
+	for i := 1; i <= 3000; i++ {
+		UpdateWorld(world)
+		if i == 2500 {
+			laid = colony.Queen.TotalEggsLaid
+		}
+	}
+
+	if colony.Queen.TotalEggsLaid <= laid {
+		t.Errorf("Queen should still be laying at tick 3000, laid %d in total and none since tick 2500",
+			colony.Queen.TotalEggsLaid)
+	}
+	if ants := colony.GetAntCount(); ants < 8 {
+		t.Errorf("Colony should have held together, only %d ants left", ants)
+	}
+}
+
+func TestQueenNeedsProteinToLay(t *testing.T) {
+	world := types.NewWorld(40, 30, random.New(1))
+	colony := types.NewColony("Red", 20, 15, types.ColonyRed)
+	colony.Food = types.FoodStore{types.Carbohydrate: 500} // Plenty of food, no protein
+	AddColony(world, colony)
+
+	for i := 0; i < 50; i++ {
+		UpdateWorld(world)
+	}
+
+	if colony.Queen.TotalEggsLaid > 0 {
+		t.Error("Queen should not lay eggs without protein")
+	}
+}
+
+// TestInterleavedWorldsMatchSoloRun checks that worlds run side by side keep
+// to themselves: stepping two same-seed worlds in turn must leave each just
+// as a world run on its own would be.
+func TestInterleavedWorldsMatchSoloRun(t *testing.T) {
+	newWorld := func() (*types.World, *types.Colony) {
+		world := types.NewWorld(60, 30, random.New(777))
+		colony := types.NewColony("Red", 15, 10, types.ColonyRed)
+		AddColony(world, colony)
+		return world, colony
+	}
+	state := func(colony *types.Colony) []types.Position {
+		var positions []types.Position
+		for _, worker := range colony.Workers {
+			positions = append(positions, worker.Position)
+		}
+		for _, nurse := range colony.Nurses {
+			positions = append(positions, nurse.Position)
+		}
+		return positions
+	}
+
+	solo, soloColony := newWorld()
+	for i := 0; i < 500; i++ {
+		UpdateWorld(solo)
+	}
+
+	first, firstColony := newWorld()
+	second, secondColony := newWorld()
+	for i := 0; i < 500; i++ {
+		UpdateWorld(first)
+		if i >= 50 { // Start the second world late so the clocks differ
+			UpdateWorld(second)
+		}
+	}
+	for i := 0; i < 50; i++ {
+		UpdateWorld(second)
+	}
+
+	want := state(soloColony)
+	for name, colony := range map[string]*types.Colony{"first": firstColony, "second": secondColony} {
+		got := state(colony)
+		if len(got) != len(want) {
+			t.Fatalf("%s world has %d ants, solo run %d", name, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s world: ant %d at %v, solo run %v", name, i, got[i], want[i])
+			}
+		}
+	}
+}
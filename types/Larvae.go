@@ -3,11 +3,14 @@ package types
 // larvae.go - Larvae structure
 // Larvae are baby ants that need nurse care to grow into workers
 
+// LarvaeMatureProgress is the growth at which a larvae matures into an adult
+const LarvaeMatureProgress = 100
+
 // Larvae represents a baby ant that hasn't matured yet
 type LarvaeAnt struct {
 	*Ant                // Embedded base ant
-	HasNurseCare   bool // Has a nurse tended to this larvae?
-	GrowthProgress int  // Progress toward becoming a full ant (0-100)
+	HasNurseCare   bool // Has a nurse taken charge of this larvae?
+	GrowthProgress int  // Progress toward becoming a full ant, advanced by feeding (0-100)
 	DestinedRole   Role // What role this larvae will become (usually Worker)
 }
 
@@ -21,6 +21,19 @@ const (
 	LarvaeMaxHealth  = 50
 )
 
+// Metabolism constants - hunger each ant type builds up per tick
+// Soldiers burn more than workers, and the egg-laying queen burns the most
+const (
+	WorkerMetabolism  = 2
+	SoldierMetabolism = 3
+	NurseMetabolism   = 2
+	QueenMetabolism   = 5
+	LarvaeMetabolism  = 1
+)
+
+// MaxInfection is the highest pathogen load an ant can carry
+const MaxInfection = 100
+
 // Role defines what job/type an ant has in the colony
 type Role int
 
@@ -32,6 +45,24 @@ const (
 	Larvae              // baby ants
 )
 
+// String returns the role's name
+func (r Role) String() string {
+	switch r {
+	case Worker:
+		return "Worker"
+	case Soldier:
+		return "Soldier"
+	case Nurse:
+		return "Nurse"
+	case Queen:
+		return "Queen"
+	case Larvae:
+		return "Larvae"
+	default:
+		return "Unknown"
+	}
+}
+
 // Position represents a coordinate in the world
 type Position struct {
 	X, Y int
@@ -41,12 +72,20 @@ type Ant struct {
 	ID            int      // Unique identifier for this ant
 	Role          Role     // What job this ant perfoms
 	Position      Position // Current position in the world grid
+	Heading       Position // Offset of the ant's last step, zero until it moves
 	Health        int
-	MaxHealth     int    // Maximum health for this ant type
-	ColonyID      string // Which colony this ant belongs to
-	Age           int    // How long the ant been alive
-	MaxAge        int    // Maximum age before dying of old age
-	CurrentAction string // What is the ant currently doing
+	MaxHealth     int                   // Maximum health for this ant type
+	ColonyID      string                // Which colony this ant belongs to
+	Age           int                   // How long the ant been alive
+	MaxAge        int                   // Maximum age before dying of old age
+	CurrentAction string                // What is the ant currently doing
+	Hunger        int                   // Hunger built up since the last meal
+	Starving      bool                  // Set while hunger is draining this ant's health
+	Infection     int                   // Pathogen load, 0 when healthy, up to MaxInfection
+	Slain         bool                  // Set when a blow in a fight takes the last of its health
+	Task          Task                  // The job the ant has taken on
+	TaskBias      [NumTasks]int         // Added to the colony's threshold for each task, so nestmates do not all switch at once
+	Former        map[Role]AntInterface // The ant as it was in each role it has left, so its history is there when it goes back
 }
 
 // NewAnt creates a new ant with the given properties
@@ -55,15 +94,40 @@ func NewAnt(id int, role Role, x, y int, colonyID string, maxHealth, maxAge int)
 		ID:            id,
 		Role:          role,
 		Position:      Position{X: x, Y: y},
+		Heading:       Position{X: 0, Y: 0},
 		Health:        100,
 		MaxHealth:     maxHealth,
 		ColonyID:      colonyID,
 		Age:           0,
 		MaxAge:        maxAge,
 		CurrentAction: "idle",
+		Hunger:        0,
+		Starving:      false,
+		Infection:     0,
+		Slain:         false,
+		Task:          taskFor(role),
+		TaskBias:      [NumTasks]int{},
+		Former:        map[Role]AntInterface{},
 	}
 }
 
+// Leave sets aside the ant as it is in its current role, to be taken up
+// again by AsWorker, AsNurse or AsSoldier if it ever goes back to that role
+func (a *Ant) Leave(role AntInterface) {
+	if a.Former == nil {
+		a.Former = map[Role]AntInterface{}
+	}
+	a.Former[role.GetRole()] = role
+}
+
+// resume takes back the ant as it was when it left a role, or nil if it
+// never had that role
+func (a *Ant) resume(role Role) AntInterface {
+	former := a.Former[role]
+	delete(a.Former, role)
+	return former
+}
+
 // AntInterface defines common behavior all ant types must implement
 type AntInterface interface {
 	GetAnt() *Ant  // Returns the default Ant
@@ -75,3 +139,38 @@ type AntInterface interface {
 func (a *Ant) IsDead() bool {
 	return a.Health <= 0 || a.Age >= a.MaxAge
 }
+
+// DeathCause records why an ant died, so starvation can be told apart from old age
+type DeathCause int
+
+const (
+	Alive      DeathCause = iota // Not dead yet
+	OldAge                       // Reached MaxAge
+	Exhaustion                   // Health worn down by digging or injury
+	Starvation                   // Health drained by hunger
+	Disease                      // Health drained by infection
+	Killed                       // Slain by an enemy ant or a predator
+)
+
+// CauseOfDeath reports why the ant died, or Alive if it has not
+func (a *Ant) CauseOfDeath() DeathCause {
+	switch {
+	case a.Age >= a.MaxAge:
+		return OldAge
+	case a.Health > 0:
+		return Alive
+	case a.Slain:
+		return Killed
+	case a.Starving:
+		return Starvation
+	case a.IsInfected():
+		return Disease
+	default:
+		return Exhaustion
+	}
+}
+
+// IsInfected reports whether the ant is carrying any pathogens
+func (a *Ant) IsInfected() bool {
+	return a.Infection > 0
+}
@@ -93,3 +93,81 @@ func TestLifespanConstants(t *testing.T) {
 		t.Error("Queen should have more health than soldiers")
 	}
 }
+
+func TestCauseOfDeath(t *testing.T) {
+	tests := []struct {
+		name     string
+		health   int
+		age      int
+		starving bool
+		expected DeathCause
+	}{
+		{"Healthy ant", 100, 0, false, Alive},
+		{"Hungry but alive", 5, 0, true, Alive},
+		{"Old age", 100, 500, false, OldAge},
+		{"Worn out", 0, 0, false, Exhaustion},
+		{"Starved", 0, 0, true, Starvation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ant := NewAnt(1, Worker, 0, 0, "test", 100, 500)
+			ant.Health = tt.health
+			ant.Age = tt.age
+			ant.Starving = tt.starving
+
+			if ant.CauseOfDeath() != tt.expected {
+				t.Errorf("CauseOfDeath() = %d, expected %d", ant.CauseOfDeath(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestMetabolismConstants(t *testing.T) {
+	// Soldiers burn more than workers, and the queen burns the most
+	if SoldierMetabolism <= WorkerMetabolism {
+		t.Error("Soldiers should burn more food than workers")
+	}
+	if QueenMetabolism <= SoldierMetabolism {
+		t.Error("Queen should burn more food than soldiers")
+	}
+}
+
+func TestCauseOfDeathDisease(t *testing.T) {
+	ant := NewAnt(1, Worker, 0, 0, "test", 100, 500)
+	ant.Health = 0
+	ant.Infection = 40
+
+	if ant.CauseOfDeath() != Disease {
+		t.Errorf("CauseOfDeath() = %d, expected %d", ant.CauseOfDeath(), Disease)
+	}
+
+	// Hunger is reported first when both wore the ant down
+	ant.Starving = true
+	if ant.CauseOfDeath() != Starvation {
+		t.Errorf("CauseOfDeath() = %d, expected %d", ant.CauseOfDeath(), Starvation)
+	}
+}
+
+func TestCauseOfDeathKilled(t *testing.T) {
+	ant := NewAnt(1, Worker, 0, 0, "test", 100, 500)
+	ant.Health = 0
+	ant.Starving = true
+	ant.Slain = true
+
+	if ant.CauseOfDeath() != Killed {
+		t.Errorf("CauseOfDeath() = %d, expected %d", ant.CauseOfDeath(), Killed)
+	}
+}
+
+func TestIsInfected(t *testing.T) {
+	ant := NewAnt(1, Worker, 0, 0, "test", 100, 500)
+	if ant.IsInfected() {
+		t.Error("New ant should be healthy")
+	}
+
+	ant.Infection = 1
+	if !ant.IsInfected() {
+		t.Error("Ant with a pathogen load should be infected")
+	}
+}
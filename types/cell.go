@@ -11,6 +11,9 @@ package types
 // therefore reads as 0.1 food.
 const FoodScale = 10
 
+// FloodedMoisture is the moisture at which a cell counts as flooded
+const FloodedMoisture = 90
+
 // Soil represents different types of terrain in the world
 type Soil int
 
@@ -28,8 +31,12 @@ type Cell struct {
 	Soil     Soil
 	IsTunnel bool
 	Occupant AntInterface // nil if empty
+	Critter  *Critter     // Non-ant creature in this cell, nil if none
+	Corpse   *Corpse      // Dead ant lying in this cell, nil if none
 	Food     int          // Food stored in this cell
-	// Moisture  int  // 0-100
+	FoodType FoodType     // What kind of food is stored here
+	Rot      int          // Decay the food here has built up
+	Moisture int          // How damp the cell is, 0-100
 	// Stability int  // How stable the cell is (affects collapse)
 }
 
@@ -40,12 +47,21 @@ func NewCell(soil Soil) *Cell {
 		Soil:     soil,
 		IsTunnel: false,
 		Occupant: nil,
+		Critter:  nil,
+		Corpse:   nil,
 		Food:     0,
-		// Moisture:  50,
+		FoodType: Carbohydrate,
+		Rot:      0,
+		Moisture: 0,
 		// Stability: 100,
 	}
 }
 
+// IsFlooded reports whether the cell is waterlogged
+func (c *Cell) IsFlooded() bool {
+	return c.Moisture >= FloodedMoisture
+}
+
 // GetIcon returns the visual character to display for this cell
 func (c *Cell) GetIcon() rune {
 	if c.Soil == Empty {
@@ -44,3 +44,15 @@ func TestCellGetIcon(t *testing.T) {
 		}
 	}
 }
+
+func TestCellIsFlooded(t *testing.T) {
+	cell := NewCell(Sand)
+	if cell.IsFlooded() {
+		t.Error("New cell should be dry")
+	}
+
+	cell.Moisture = FloodedMoisture
+	if !cell.IsFlooded() {
+		t.Error("Cell at flood moisture should be flooded")
+	}
+}
@@ -0,0 +1,107 @@
+package types
+
+// chamber.go - The rooms a colony plans and digs out
+// A colony lays out its nest rather than living wherever the tunnels happen to
+// go: a chamber for the queen, a nursery for the brood and a granary for the
+// stores, joined to the queen's chamber by galleries, with a shaft up to the
+// surface and a midden beside it. Chambers are rectangles around a centre and
+// widen as the colony grows.
+
+// ChamberKind is what a chamber is for
+type ChamberKind int
+
+const (
+	QueenChamber  ChamberKind = iota // Where the queen lives and lays
+	Nursery                          // Where larvae are kept
+	Granary                          // Where food is stored
+	MiddenChamber                    // Where the dead are dumped, a single cell on the surface
+)
+
+// String returns the chamber kind's name
+func (k ChamberKind) String() string {
+	switch k {
+	case QueenChamber:
+		return "queen's chamber"
+	case Nursery:
+		return "nursery"
+	case Granary:
+		return "granary"
+	case MiddenChamber:
+		return "midden"
+	default:
+		return "chamber"
+	}
+}
+
+// Chamber is a rectangular room in the nest
+type Chamber struct {
+	Kind       ChamberKind
+	Center     Position
+	HalfWidth  int  // Cells either side of the centre
+	HalfHeight int  // Cells above and below the centre
+	Open       bool // A tunnel joins the centre to the queen, so ants can use the chamber
+}
+
+// NewChamber creates a planned chamber that has not been dug yet
+func NewChamber(kind ChamberKind, x, y, halfWidth, halfHeight int) *Chamber {
+	return &Chamber{
+		Kind:       kind,
+		Center:     Position{X: x, Y: y},
+		HalfWidth:  halfWidth,
+		HalfHeight: halfHeight,
+		Open:       false,
+	}
+}
+
+// Contains reports whether a cell lies inside the chamber
+func (c *Chamber) Contains(pos Position) bool {
+	dx, dy := pos.X-c.Center.X, pos.Y-c.Center.Y
+	return dx >= -c.HalfWidth && dx <= c.HalfWidth && dy >= -c.HalfHeight && dy <= c.HalfHeight
+}
+
+// Cells lists every cell in the chamber, row by row
+func (c *Chamber) Cells() []Position {
+	var cells []Position
+	for y := c.Center.Y - c.HalfHeight; y <= c.Center.Y+c.HalfHeight; y++ {
+		for x := c.Center.X - c.HalfWidth; x <= c.Center.X+c.HalfWidth; x++ {
+			cells = append(cells, Position{X: x, Y: y})
+		}
+	}
+	return cells
+}
+
+// GetIcon returns the display icon for an empty chamber floor, or for the
+// refuse heaped on a midden
+func (c *Chamber) GetIcon() rune {
+	if c.Kind == MiddenChamber {
+		return '▲'
+	}
+	return '▢'
+}
+
+// Gallery is a passage planned between two points
+type Gallery struct {
+	From Position
+	To   Position
+}
+
+// Cells lists every cell along the gallery: along From's row first, then
+// along To's column, so the passage has a single bend
+func (g Gallery) Cells() []Position {
+	var cells []Position
+	step := 1
+	if g.To.X < g.From.X {
+		step = -1
+	}
+	for x := g.From.X; x != g.To.X; x += step {
+		cells = append(cells, Position{X: x, Y: g.From.Y})
+	}
+	step = 1
+	if g.To.Y < g.From.Y {
+		step = -1
+	}
+	for y := g.From.Y; y != g.To.Y; y += step {
+		cells = append(cells, Position{X: g.To.X, Y: y})
+	}
+	return append(cells, g.To)
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestChamberContains(t *testing.T) {
+	chamber := NewChamber(Nursery, 10, 10, 2, 1)
+
+	if !chamber.Contains(Position{X: 8, Y: 9}) || !chamber.Contains(Position{X: 12, Y: 11}) {
+		t.Error("Expected the corners to be inside the chamber")
+	}
+	if chamber.Contains(Position{X: 13, Y: 10}) || chamber.Contains(Position{X: 10, Y: 12}) {
+		t.Error("Expected cells past the edges to be outside the chamber")
+	}
+	if chamber.Open {
+		t.Error("A new chamber should not be open")
+	}
+}
+
+func TestChamberCells(t *testing.T) {
+	chamber := NewChamber(Granary, 10, 10, 2, 1)
+	cells := chamber.Cells()
+
+	if len(cells) != 15 {
+		t.Fatalf("Expected 15 cells, got %d", len(cells))
+	}
+	for _, pos := range cells {
+		if !chamber.Contains(pos) {
+			t.Errorf("Cell %v is not in the chamber", pos)
+		}
+	}
+}
+
+func TestGalleryCells(t *testing.T) {
+	gallery := Gallery{From: Position{X: 10, Y: 5}, To: Position{X: 7, Y: 8}}
+	cells := gallery.Cells()
+
+	want := []Position{{10, 5}, {9, 5}, {8, 5}, {7, 5}, {7, 6}, {7, 7}, {7, 8}}
+	if len(cells) != len(want) {
+		t.Fatalf("Expected %d cells, got %v", len(want), cells)
+	}
+	for i := range want {
+		if cells[i] != want[i] {
+			t.Errorf("Cell %d: expected %v, got %v", i, want[i], cells[i])
+		}
+	}
+
+	same := Gallery{From: Position{X: 3, Y: 3}, To: Position{X: 3, Y: 3}}
+	if got := same.Cells(); len(got) != 1 || got[0] != same.To {
+		t.Errorf("A gallery to itself should be one cell, got %v", got)
+	}
+}
+
+func TestChamberKindString(t *testing.T) {
+	tests := map[ChamberKind]string{
+		QueenChamber:    "queen's chamber",
+		Nursery:         "nursery",
+		Granary:         "granary",
+		MiddenChamber:   "midden",
+		ChamberKind(99): "chamber",
+	}
+	for kind, want := range tests {
+		if got := kind.String(); got != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	}
+}
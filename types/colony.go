@@ -14,22 +14,52 @@ const (
 	ColonyPurple
 )
 
+// MaxSightings is how many recent threats a colony keeps track of
+const MaxSightings = 8
+
+// Sighting is where and when a threat was last seen
+type Sighting struct {
+	Position Position
+	Tick     int
+}
+
 // Colony represents a group of ants that work together
 // Contains the queen, all ants, shared resources, and colony identity
 type Colony struct {
-	Name          string        // Colony identifier (e.g. "Red", "Black")
-	Color         ColonyColor   // Palette slot for this colony's ants
-	Queen         *QueenAnt     // The reigning queen (center of the colony)
-	Queens        []*QueenAnt   // Spare queens raised from larvae, heirs to the throne
-	HeadNurse     *NurseAnt     // The primary nurse ant (second in command)
-	Nurses        []*NurseAnt   // All other nurse ants
-	Workers       []*WorkerAnt  // All worker ants
-	Soldiers      []*SoldierAnt // All soldier ants
-	Larvae        []*LarvaeAnt  // All larvae waiting to grow
-	Food          int           // Shared food stockpile
-	Eggs          int           // Number of eggs waiting to hatch
-	NextAntID     int           // Counter for generating unique ant IDs
-	QueenPosition Position      // Position of the queen (center of colony)
+	Name          string             // Colony identifier (e.g. "Red", "Black")
+	Color         ColonyColor        // Palette slot for this colony's ants
+	Queen         *QueenAnt          // The reigning queen (center of the colony)
+	Queens        []*QueenAnt        // Spare queens raised from larvae, heirs to the throne
+	HeadNurse     *NurseAnt          // The primary nurse ant (second in command)
+	Nurses        []*NurseAnt        // All other nurse ants
+	Workers       []*WorkerAnt       // All worker ants
+	Soldiers      []*SoldierAnt      // All soldier ants
+	Larvae        []*LarvaeAnt       // All larvae waiting to grow
+	Food          FoodStore          // Shared food stockpile, by type
+	Spoiled       int                // Food lost from the stockpile to decay, in FoodScale units
+	Deposits      int                // Loads of food workers have delivered to the stores or garden
+	Kills         int                // Enemy ants and predators the colony's ants have killed
+	Territory     int                // Cells the colony holds
+	Eggs          int                // Number of eggs waiting to hatch
+	NextAntID     int                // Counter for generating unique ant IDs
+	QueenPosition Position           // Position of the queen (center of colony)
+	HomeField     *DistanceField     // Steps to the queen from every tunnel cell, nil until the colony is placed
+	Movers        map[Role]string    // Movement strategy each role uses, by name; roles not listed use the default
+	Deaths        map[DeathCause]int // How many ants have died of each cause
+	Sightings     []Sighting         // Recent threats seen by the colony's ants, oldest first
+	Garden        *FungusGarden      // The colony's fungus farm, nil until founded
+	Midden        *Midden            // The colony's refuse pile, nil until founded
+	Chambers      []*Chamber         // The rooms the colony has planned, dug or not
+	Galleries     []Gallery          // Passages joining the chambers and the surface
+	Stores        *Chamber           // Chamber the food is kept in, nil while it sits with the queen
+	Haul          *StoresHaul        // The stores being carried somewhere new, nil unless they are
+}
+
+// StoresHaul is the colony's food being carried, a load at a time, to a new
+// place. The food still counts as kept where it was until the last load is in
+type StoresHaul struct {
+	To    *Chamber // Chamber the stores are going to, nil for the queen's side
+	Moved int      // Food already carried there
 }
 
 // NewColony creates a new ant colony with a queen and head nurse at the specified position
@@ -42,19 +72,35 @@ func NewColony(name string, queenX, queenY int, color ColonyColor) *Colony {
 	firstWorker := NewWorker(2, queenX-1, queenY, name) // First worker starts on her other side
 
 	return &Colony{
-		Name:          name,
-		Color:         color,
-		Queen:         queen,
-		HeadNurse:     headNurse,
-		Queens:        []*QueenAnt{},
-		Nurses:        []*NurseAnt{},
-		Workers:       []*WorkerAnt{firstWorker},
-		Soldiers:      []*SoldierAnt{},
-		Larvae:        []*LarvaeAnt{},
-		Food:          50 * FoodScale, // Starting food, 50 food
+		Name:      name,
+		Color:     color,
+		Queen:     queen,
+		HeadNurse: headNurse,
+		Queens:    []*QueenAnt{},
+		Nurses:    []*NurseAnt{},
+		Workers:   []*WorkerAnt{firstWorker},
+		Soldiers:  []*SoldierAnt{},
+		Larvae:    []*LarvaeAnt{},
+		Food: FoodStore{ // Starting food, 50 food
+			Carbohydrate: 35 * FoodScale,
+			Protein:      15 * FoodScale,
+		},
 		Eggs:          0,
+		Deposits:      0,
+		Kills:         0,
+		Territory:     0,
 		NextAntID:     3, // Start at 3: queen=0, head nurse=1, first worker=2
 		QueenPosition: Position{queenX, queenY},
+		HomeField:     nil,
+		Movers:        map[Role]string{},
+		Deaths:        map[DeathCause]int{},
+		Sightings:     []Sighting{},
+		Garden:        nil,
+		Midden:        nil,
+		Chambers:      nil,
+		Galleries:     nil,
+		Stores:        nil,
+		Haul:          nil,
 	}
 }
 
@@ -111,3 +157,59 @@ func (c *Colony) GetAntCount() int {
 	count += len(c.Larvae)
 	return count
 }
+
+// CountInfected returns how many of the colony's ants are carrying pathogens
+func (c *Colony) CountInfected() int {
+	sick := 0
+	for _, ant := range c.GetAllAnts() {
+		if ant.GetAnt().IsInfected() {
+			sick++
+		}
+	}
+	return sick
+}
+
+// RecordDeath tallies one death under the given cause
+func (c *Colony) RecordDeath(cause DeathCause) {
+	if c.Deaths == nil {
+		c.Deaths = map[DeathCause]int{}
+	}
+	c.Deaths[cause]++
+}
+
+// StorePosition returns where the colony keeps its food
+// Ants deliver food here and come back here to eat. The stores sit with the
+// queen until the colony moves them into a chamber that has been dug.
+func (c *Colony) StorePosition() Position {
+	if c.Stores != nil && c.Stores.Open {
+		return c.Stores.Center
+	}
+	return c.QueenPosition
+}
+
+// Chamber returns the colony's chamber of a kind, or nil if it has none
+func (c *Colony) Chamber(kind ChamberKind) *Chamber {
+	for _, chamber := range c.Chambers {
+		if chamber.Kind == kind {
+			return chamber
+		}
+	}
+	return nil
+}
+
+// ReportThreat records a threat seen at pos
+// A sighting within two cells of an earlier one updates it rather than adding
+// another, and the oldest sighting is dropped once the list is full
+func (c *Colony) ReportThreat(pos Position, tick int) {
+	for i, s := range c.Sightings {
+		dx, dy := s.Position.X-pos.X, s.Position.Y-pos.Y
+		if dx >= -2 && dx <= 2 && dy >= -2 && dy <= 2 {
+			c.Sightings = append(c.Sightings[:i], c.Sightings[i+1:]...)
+			break
+		}
+	}
+	c.Sightings = append(c.Sightings, Sighting{Position: pos, Tick: tick})
+	if len(c.Sightings) > MaxSightings {
+		c.Sightings = c.Sightings[1:]
+	}
+}
@@ -23,8 +23,12 @@ func TestNewColony(t *testing.T) {
 	if len(colony.Workers) != 1 {
 		t.Errorf("Expected 1 founding worker, got %d", len(colony.Workers))
 	}
-	if colony.Food != 50*FoodScale {
-		t.Errorf("Expected Food %d, got %d", 50*FoodScale, colony.Food)
+	if colony.Food.Total() != 50*FoodScale {
+		t.Errorf("Expected Food %d, got %d", 50*FoodScale, colony.Food.Total())
+	}
+	// Founders need some protein, or the first brood can never be raised
+	if colony.Food[Protein] == 0 {
+		t.Error("Expected starting protein")
 	}
 	if colony.NextAntID != 3 {
 		t.Errorf("Expected NextAntID 3, got %d", colony.NextAntID)
@@ -60,3 +64,71 @@ func TestGetAntCount(t *testing.T) {
 		t.Errorf("Expected count 5, got %d", colony.GetAntCount())
 	}
 }
+
+func TestColonyCountInfected(t *testing.T) {
+	colony := NewColony("Red", 20, 15, ColonyRed)
+	colony.Workers[0].Infection = 10
+
+	if got := colony.CountInfected(); got != 1 {
+		t.Errorf("Expected 1 infected ant, got %d", got)
+	}
+}
+
+func TestRecordDeath(t *testing.T) {
+	colony := NewColony("Red", 10, 20, ColonyRed)
+
+	colony.RecordDeath(Starvation)
+	colony.RecordDeath(Starvation)
+	colony.RecordDeath(OldAge)
+
+	if colony.Deaths[Starvation] != 2 {
+		t.Errorf("Expected 2 starvation deaths, got %d", colony.Deaths[Starvation])
+	}
+	if colony.Deaths[OldAge] != 1 {
+		t.Errorf("Expected 1 old age death, got %d", colony.Deaths[OldAge])
+	}
+}
+
+func TestColonyReportThreat(t *testing.T) {
+	colony := NewColony("Red", 10, 10, ColonyRed)
+
+	colony.ReportThreat(Position{X: 5, Y: 5}, 1)
+	colony.ReportThreat(Position{X: 6, Y: 6}, 2)
+	if len(colony.Sightings) != 1 || colony.Sightings[0].Tick != 2 {
+		t.Fatalf("A nearby sighting should update the earlier one, got %v", colony.Sightings)
+	}
+
+	for i := 0; i < MaxSightings; i++ {
+		colony.ReportThreat(Position{X: 20 + 5*i, Y: 5}, 10+i)
+	}
+	if len(colony.Sightings) != MaxSightings {
+		t.Fatalf("Expected %d sightings kept, got %d", MaxSightings, len(colony.Sightings))
+	}
+	if colony.Sightings[0].Position == (Position{X: 6, Y: 6}) {
+		t.Error("The oldest sighting should have been dropped")
+	}
+}
+
+func TestStorePositionFollowsStores(t *testing.T) {
+	colony := NewColony("Red", 10, 10, ColonyRed)
+	if colony.StorePosition() != colony.QueenPosition {
+		t.Error("With no granary the stores should be with the queen")
+	}
+
+	granary := NewChamber(Granary, 15, 10, 1, 1)
+	colony.Chambers = []*Chamber{NewChamber(QueenChamber, 10, 10, 1, 1), granary}
+	if colony.Chamber(Granary) != granary {
+		t.Fatal("Expected Chamber to find the granary")
+	}
+	if colony.Chamber(Nursery) != nil {
+		t.Error("Expected no nursery")
+	}
+	colony.Stores = granary
+	if colony.StorePosition() != colony.QueenPosition {
+		t.Error("The stores should stay with the queen until the granary is dug")
+	}
+	granary.Open = true
+	if colony.StorePosition() != granary.Center {
+		t.Errorf("Expected the stores in the granary at %v, got %v", granary.Center, colony.StorePosition())
+	}
+}
@@ -0,0 +1,44 @@
+package types
+
+// corpse.go - Dead ants and the midden they are carried to
+// A dead ant leaves its body in the cell where it fell. Bodies block the way
+// until a worker clears them, and a colony piles its own dead on a midden
+// outside the nest so they do not fester underground.
+
+// Corpse is the body of a dead ant lying in the world
+type Corpse struct {
+	Position Position // Where the body lies
+	Role     Role     // What the ant was in life
+	ColonyID string   // Colony the ant belonged to
+	Age      int      // Ticks the body has been lying here
+}
+
+// NewCorpse creates the body of the given ant where it died
+func NewCorpse(ant *Ant) *Corpse {
+	return &Corpse{
+		Position: ant.Position,
+		Role:     ant.Role,
+		ColonyID: ant.ColonyID,
+		Age:      0,
+	}
+}
+
+// GetIcon returns the display icon for the corpse
+func (c *Corpse) GetIcon() rune {
+	return '✝'
+}
+
+// Midden is a colony's refuse pile on the surface
+// Its place is one of the colony's chambers, a single cell with nothing to dig
+type Midden struct {
+	*Chamber     // Where the colony dumps its dead
+	Corpses  int // How many bodies have been dumped here
+}
+
+// NewMidden creates an empty midden at the given position
+func NewMidden(x, y int) *Midden {
+	return &Midden{
+		Chamber: NewChamber(MiddenChamber, x, y, 0, 0),
+		Corpses: 0,
+	}
+}
@@ -0,0 +1,33 @@
+package types
+
+import "testing"
+
+func TestNewCorpse(t *testing.T) {
+	ant := NewAnt(5, Soldier, 7, 12, "Red", 100, 500)
+
+	corpse := NewCorpse(ant)
+
+	if corpse.Position.X != 7 || corpse.Position.Y != 12 {
+		t.Errorf("Expected corpse at (7,12), got (%d,%d)", corpse.Position.X, corpse.Position.Y)
+	}
+	if corpse.Role != Soldier {
+		t.Errorf("Expected Role Soldier, got %d", corpse.Role)
+	}
+	if corpse.ColonyID != "Red" {
+		t.Errorf("Expected ColonyID 'Red', got '%s'", corpse.ColonyID)
+	}
+	if corpse.Age != 0 {
+		t.Errorf("Expected Age 0, got %d", corpse.Age)
+	}
+}
+
+func TestNewMidden(t *testing.T) {
+	midden := NewMidden(20, 1)
+
+	if midden.Center.X != 20 || midden.Center.Y != 1 {
+		t.Errorf("Expected midden at (20,1), got (%d,%d)", midden.Center.X, midden.Center.Y)
+	}
+	if midden.Corpses != 0 {
+		t.Errorf("Expected an empty midden, got %d corpses", midden.Corpses)
+	}
+}
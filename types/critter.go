@@ -0,0 +1,73 @@
+package types
+
+// critter.go - Creatures in the world that are not ants
+// Critters share the grid with ants but live in their own cell slot, so an
+// aphid or a spider blocks a cell without pretending to be an ant
+
+// CritterKind identifies what sort of creature a critter is
+type CritterKind int
+
+const (
+	Aphid  CritterKind = iota // Sap-sucker on surface plants, produces honeydew
+	Spider                    // Surface predator, hunts aphids and ants
+)
+
+// Critter health constants
+const (
+	AphidMaxHealth  = 10
+	SpiderMaxHealth = 60
+)
+
+// Critter is a non-ant creature living on the surface
+type Critter struct {
+	ID       int         // Unique identifier for this critter
+	Kind     CritterKind // What sort of creature this is
+	Position Position    // Current position in the world grid
+	Health   int
+	Age      int    // How long the critter has been in the world
+	Honeydew int    // Honeydew built up and ready to be milked, in food units
+	HerdedBy string // Colony that milks and guards this aphid, "" if wild
+}
+
+// NewAphid creates a wild aphid at the specified position
+func NewAphid(id int, x, y int) *Critter {
+	return &Critter{
+		ID:       id,
+		Kind:     Aphid,
+		Position: Position{X: x, Y: y},
+		Health:   AphidMaxHealth,
+		Age:      0,
+		Honeydew: 0,
+		HerdedBy: "",
+	}
+}
+
+// NewSpider creates a spider at the specified position
+func NewSpider(id int, x, y int) *Critter {
+	return &Critter{
+		ID:       id,
+		Kind:     Spider,
+		Position: Position{X: x, Y: y},
+		Health:   SpiderMaxHealth,
+		Age:      0,
+		Honeydew: 0,
+		HerdedBy: "",
+	}
+}
+
+// IsDead checks if the critter has been killed
+func (c *Critter) IsDead() bool {
+	return c.Health <= 0
+}
+
+// GetIcon returns the display icon for the critter
+func (c *Critter) GetIcon() rune {
+	switch c.Kind {
+	case Aphid:
+		return '¤'
+	case Spider:
+		return '✱'
+	default:
+		return '?'
+	}
+}
@@ -0,0 +1,49 @@
+package types
+
+import "testing"
+
+func TestNewAphid(t *testing.T) {
+	aphid := NewAphid(3, 10, 1)
+
+	if aphid.ID != 3 {
+		t.Errorf("Expected ID 3, got %d", aphid.ID)
+	}
+	if aphid.Kind != Aphid {
+		t.Errorf("Expected Kind Aphid, got %d", aphid.Kind)
+	}
+	if aphid.Health != AphidMaxHealth {
+		t.Errorf("Expected Health %d, got %d", AphidMaxHealth, aphid.Health)
+	}
+	if aphid.HerdedBy != "" {
+		t.Errorf("Expected a wild aphid, herded by '%s'", aphid.HerdedBy)
+	}
+}
+
+func TestNewSpider(t *testing.T) {
+	spider := NewSpider(4, 10, 1)
+
+	if spider.Kind != Spider {
+		t.Errorf("Expected Kind Spider, got %d", spider.Kind)
+	}
+	if spider.Health != SpiderMaxHealth {
+		t.Errorf("Expected Health %d, got %d", SpiderMaxHealth, spider.Health)
+	}
+}
+
+func TestCritterIsDead(t *testing.T) {
+	aphid := NewAphid(1, 0, 1)
+	if aphid.IsDead() {
+		t.Error("New aphid should be alive")
+	}
+
+	aphid.Health = 0
+	if !aphid.IsDead() {
+		t.Error("Aphid with no health should be dead")
+	}
+}
+
+func TestCritterGetIcon(t *testing.T) {
+	if NewAphid(1, 0, 1).GetIcon() == NewSpider(2, 0, 1).GetIcon() {
+		t.Error("Aphids and spiders should have different icons")
+	}
+}
@@ -0,0 +1,174 @@
+package types
+
+// distancefield.go - How far every tunnel cell is from a colony's queen
+// A distance field holds, for each open cell, the number of steps to the
+// queen through open tunnel, diagonals included. An ant heading home just
+// steps to a neighbour with a smaller distance, so nobody has to search for a
+// route. The field is built once with a breadth-first search, then patched
+// locally as tunnels are dug or fill in. Only when the queen herself moves is
+// it built again from scratch.
+
+// Unreachable is the distance of a cell with no open way to the queen
+const Unreachable = 1<<31 - 1
+
+// DistanceField holds the steps from every cell to one source cell
+type DistanceField struct {
+	Width, Height int
+	Source        Position // Cell distances are measured to, the queen's
+	Dist          []int    // Steps to Source per cell, indexed like World.Cells
+}
+
+// NewDistanceField builds the distance field to source over the world's tunnels
+func NewDistanceField(world *World, source Position) *DistanceField {
+	f := &DistanceField{
+		Width:  world.Width,
+		Height: world.Height,
+		Source: source,
+		Dist:   make([]int, world.Width*world.Height),
+	}
+	f.Rebuild(world, source)
+	return f
+}
+
+// At returns the steps from a cell to the source, or Unreachable
+func (f *DistanceField) At(x, y int) int {
+	if x < 0 || x >= f.Width || y < 0 || y >= f.Height {
+		return Unreachable
+	}
+	return f.Dist[y*f.Width+x]
+}
+
+// Rebuild measures every distance again from a new source
+func (f *DistanceField) Rebuild(world *World, source Position) {
+	for i := range f.Dist {
+		f.Dist[i] = Unreachable
+	}
+	f.Source = source
+	if !world.IsValidPosition(source.X, source.Y) {
+		return
+	}
+	start := world.Index(source.X, source.Y)
+	f.Dist[start] = 0
+	f.spread(world, []int{start})
+}
+
+// Track rebuilds the field if the queen is no longer where it was measured from
+func (f *DistanceField) Track(world *World, source Position) {
+	if source != f.Source {
+		f.Rebuild(world, source)
+	}
+}
+
+// Opened patches the field for a cell that has just been dug
+// The new cell takes its distance from its nearest neighbour, and any cell
+// it makes a shortcut for is lowered in turn
+func (f *DistanceField) Opened(world *World, x, y int) {
+	best := Unreachable
+	for _, n := range f.openNeighbours(world, world.Index(x, y)) {
+		best = min(best, f.Dist[n])
+	}
+	if best == Unreachable {
+		return // Dug out of solid ground, nowhere near the nest
+	}
+
+	i := world.Index(x, y)
+	f.Dist[i] = best + 1
+	f.spread(world, []int{i})
+}
+
+// Closed patches the field for a cell that has just filled in
+// Every cell whose only shortest way home went through it is cut loose, then
+// those cells are measured again from whatever intact cells border them.
+func (f *DistanceField) Closed(world *World, x, y int) {
+	i := world.Index(x, y)
+	if f.Dist[i] == Unreachable {
+		return
+	}
+	if f.Source == (Position{X: x, Y: y}) {
+		f.Rebuild(world, f.Source)
+		return
+	}
+
+	// Cut loose each cell left without a neighbour one step nearer home. A
+	// cell that loses its last such neighbour puts its own children up for
+	// the same check, so the whole stranded branch goes.
+	old := f.Dist[i]
+	f.Dist[i] = Unreachable
+	var lost []int
+	queue := f.children(world, i, old)
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if f.Dist[c] == Unreachable || f.hasParent(world, c) {
+			continue
+		}
+		d := f.Dist[c]
+		f.Dist[c] = Unreachable
+		lost = append(lost, c)
+		queue = append(queue, f.children(world, c, d)...)
+	}
+
+	// Measure the stranded cells again from the intact cells around them
+	var seeds []int
+	for _, c := range lost {
+		best := Unreachable
+		for _, n := range f.openNeighbours(world, c) {
+			best = min(best, f.Dist[n])
+		}
+		if best != Unreachable {
+			f.Dist[c] = best + 1
+			seeds = append(seeds, c)
+		}
+	}
+	f.spread(world, seeds)
+}
+
+// spread lowers distances outward from the given cells until nothing changes
+func (f *DistanceField) spread(world *World, queue []int) {
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for _, n := range f.openNeighbours(world, i) {
+			if f.Dist[i]+1 < f.Dist[n] {
+				f.Dist[n] = f.Dist[i] + 1
+				queue = append(queue, n)
+			}
+		}
+	}
+}
+
+// hasParent reports whether a cell has an open neighbour one step nearer home
+func (f *DistanceField) hasParent(world *World, i int) bool {
+	for _, n := range f.openNeighbours(world, i) {
+		if f.Dist[n] == f.Dist[i]-1 {
+			return true
+		}
+	}
+	return false
+}
+
+// children returns the open neighbours of a cell that were one step further
+// from home than its distance d
+func (f *DistanceField) children(world *World, i, d int) []int {
+	var out []int
+	for _, n := range f.openNeighbours(world, i) {
+		if f.Dist[n] == d+1 {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// openNeighbours returns the indexes of the open tunnel cells around a cell,
+// in the fixed neighbour order
+func (f *DistanceField) openNeighbours(world *World, i int) []int {
+	x, y := i%f.Width, i/f.Width
+	var out []int
+	for _, offset := range pheromoneNeighbours {
+		cell := world.GetCell(x+offset[0], y+offset[1])
+		if cell != nil && cell.IsTunnel {
+			out = append(out, world.Index(x+offset[0], y+offset[1]))
+		}
+	}
+	return out
+}
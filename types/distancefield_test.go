@@ -0,0 +1,136 @@
+package types
+
+import (
+	"antfarm/random"
+	"testing"
+)
+
+func TestDistanceFieldMeasuresThroughTunnels(t *testing.T) {
+	world := NewWorld(20, 20, random.New(1))
+	for x := 5; x <= 10; x++ {
+		world.GetCell(x, 10).IsTunnel = true
+	}
+
+	field := NewDistanceField(world, Position{X: 5, Y: 10})
+
+	if got := field.At(5, 10); got != 0 {
+		t.Errorf("Source should be 0 away, got %d", got)
+	}
+	if got := field.At(10, 10); got != 5 {
+		t.Errorf("Expected (10,10) 5 steps away, got %d", got)
+	}
+	if got := field.At(5, 12); got != Unreachable {
+		t.Errorf("Solid ground should be unreachable, got %d", got)
+	}
+	if got := field.At(-1, 10); got != Unreachable {
+		t.Errorf("Outside the world should be unreachable, got %d", got)
+	}
+}
+
+func TestDistanceFieldOpenedMakesShortcut(t *testing.T) {
+	world := NewWorld(20, 20, random.New(1))
+	// A U-shaped tunnel: down, along and back up
+	for y := 5; y <= 9; y++ {
+		world.GetCell(5, y).IsTunnel = true
+		world.GetCell(9, y).IsTunnel = true
+	}
+	for x := 5; x <= 9; x++ {
+		world.GetCell(x, 9).IsTunnel = true
+	}
+	world.Colonies = append(world.Colonies, NewColony("Red", 5, 5, ColonyRed))
+	field := NewDistanceField(world, Position{X: 5, Y: 5})
+	world.Colonies[0].HomeField = field
+	before := field.At(9, 5)
+
+	// Dig straight across the top
+	for x := 6; x <= 8; x++ {
+		world.Dig(x, 5)
+	}
+
+	if got := field.At(9, 5); got != 4 || got >= before {
+		t.Errorf("Expected the shortcut to bring (9,5) to 4 steps from %d, got %d", before, got)
+	}
+}
+
+func TestDistanceFieldClosedReroutesAndStrands(t *testing.T) {
+	world := NewWorld(20, 20, random.New(1))
+	for x := 5; x <= 12; x++ {
+		world.GetCell(x, 5).IsTunnel = true
+	}
+	// A longer way round under part of the tunnel
+	for x := 7; x <= 10; x++ {
+		world.GetCell(x, 7).IsTunnel = true
+	}
+	world.GetCell(7, 6).IsTunnel = true
+	world.GetCell(10, 6).IsTunnel = true
+	world.Colonies = append(world.Colonies, NewColony("Red", 5, 5, ColonyRed))
+	field := NewDistanceField(world, Position{X: 5, Y: 5})
+	world.Colonies[0].HomeField = field
+
+	world.Fill(8, 5)
+	world.Fill(8, 6)
+	world.Fill(9, 6)
+
+	fresh := NewDistanceField(world, Position{X: 5, Y: 5})
+	for i := range field.Dist {
+		if field.Dist[i] != fresh.Dist[i] {
+			t.Fatalf("Patched field differs from a fresh one at (%d,%d): %d vs %d",
+				i%world.Width, i/world.Width, field.Dist[i], fresh.Dist[i])
+		}
+	}
+	if got := field.At(12, 5); got == Unreachable {
+		t.Error("(12,5) should still be reachable the long way round")
+	}
+
+	world.Fill(7, 6)
+	world.Fill(6, 5)
+	if got := field.At(12, 5); got != Unreachable {
+		t.Errorf("(12,5) should be cut off, got %d", got)
+	}
+}
+
+func TestDistanceFieldPatchesMatchRebuild(t *testing.T) {
+	world := NewWorld(30, 20, random.New(3))
+	world.Colonies = append(world.Colonies, NewColony("Red", 15, 10, ColonyRed))
+	world.GetCell(15, 10).IsTunnel = true
+	field := NewDistanceField(world, Position{X: 15, Y: 10})
+	world.Colonies[0].HomeField = field
+
+	r := random.New(7)
+	for step := 0; step < 2000; step++ {
+		x, y := int(r.Below(30)), 2+int(r.Below(18))
+		if x == 15 && y == 10 {
+			continue
+		}
+		if r.Chance(70) {
+			world.Dig(x, y)
+		} else {
+			world.Fill(x, y)
+		}
+	}
+
+	fresh := NewDistanceField(world, Position{X: 15, Y: 10})
+	for i := range field.Dist {
+		if field.Dist[i] != fresh.Dist[i] {
+			t.Fatalf("Patched field differs from a fresh one at (%d,%d): %d vs %d",
+				i%world.Width, i/world.Width, field.Dist[i], fresh.Dist[i])
+		}
+	}
+}
+
+func TestDistanceFieldTrack(t *testing.T) {
+	world := NewWorld(20, 20, random.New(1))
+	for x := 5; x <= 10; x++ {
+		world.GetCell(x, 10).IsTunnel = true
+	}
+	field := NewDistanceField(world, Position{X: 5, Y: 10})
+
+	field.Track(world, Position{X: 6, Y: 10})
+
+	if field.Source != (Position{X: 6, Y: 10}) {
+		t.Errorf("Field should now measure from (6,10), got %v", field.Source)
+	}
+	if got := field.At(10, 10); got != 4 {
+		t.Errorf("Expected (10,10) 4 steps from the moved queen, got %d", got)
+	}
+}
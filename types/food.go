@@ -0,0 +1,56 @@
+package types
+
+// food.go - Food types and the stores that hold them
+// Food comes in kinds with different nutritional roles, so a colony needs a
+// foraging mix rather than just a big pile
+
+// FoodType identifies what kind of food something is
+type FoodType int
+
+const (
+	Carbohydrate FoodType = iota // Seeds and grass, fuels adult activity
+	Protein                      // Insect prey, needed to raise brood and lay eggs
+	Sugar                        // Aphid honeydew, quick fuel for adults
+	NumFoodTypes                 // Number of food types, for sizing stores
+)
+
+// FoodStore holds an amount of each food type, in FoodScale units
+// It is a fixed array indexed by FoodType so it stays integer-only and ports
+// straight to the firmware
+type FoodStore [NumFoodTypes]int
+
+// Total returns the amount of food of every type combined
+func (s *FoodStore) Total() int {
+	total := 0
+	for _, amount := range s {
+		total += amount
+	}
+	return total
+}
+
+// Available returns how much food of the listed types is in the store
+func (s *FoodStore) Available(diet ...FoodType) int {
+	total := 0
+	for _, foodType := range diet {
+		total += s[foodType]
+	}
+	return total
+}
+
+// Spend takes up to units of food from the store, drawing from the listed
+// types in order, and returns how much it actually took
+func (s *FoodStore) Spend(units int, diet ...FoodType) int {
+	spent := 0
+	for _, foodType := range diet {
+		take := units - spent
+		if take > s[foodType] {
+			take = s[foodType]
+		}
+		s[foodType] -= take
+		spent += take
+		if spent == units {
+			break
+		}
+	}
+	return spent
+}
@@ -0,0 +1,51 @@
+package types
+
+import "testing"
+
+func TestFoodStoreTotal(t *testing.T) {
+	store := FoodStore{Carbohydrate: 30, Protein: 10, Sugar: 5}
+
+	if store.Total() != 45 {
+		t.Errorf("Expected Total 45, got %d", store.Total())
+	}
+}
+
+func TestFoodStoreAvailable(t *testing.T) {
+	store := FoodStore{Carbohydrate: 30, Protein: 10, Sugar: 5}
+
+	if store.Available(Sugar, Carbohydrate) != 35 {
+		t.Errorf("Expected 35 carbohydrate and sugar, got %d", store.Available(Sugar, Carbohydrate))
+	}
+	if store.Available(Protein) != 10 {
+		t.Errorf("Expected 10 protein, got %d", store.Available(Protein))
+	}
+}
+
+func TestFoodStoreSpendInDietOrder(t *testing.T) {
+	store := FoodStore{Carbohydrate: 30, Sugar: 5}
+
+	spent := store.Spend(8, Sugar, Carbohydrate)
+
+	if spent != 8 {
+		t.Errorf("Expected to spend 8, got %d", spent)
+	}
+	if store[Sugar] != 0 {
+		t.Errorf("Sugar should be drawn down first, %d left", store[Sugar])
+	}
+	if store[Carbohydrate] != 27 {
+		t.Errorf("Expected 27 carbohydrate left, got %d", store[Carbohydrate])
+	}
+}
+
+func TestFoodStoreSpendMoreThanAvailable(t *testing.T) {
+	store := FoodStore{Carbohydrate: 30, Protein: 4}
+
+	spent := store.Spend(10, Protein)
+
+	if spent != 4 {
+		t.Errorf("Expected to spend only the 4 protein held, got %d", spent)
+	}
+	if store[Carbohydrate] != 30 {
+		t.Error("Spending protein should not touch carbohydrate")
+	}
+}
@@ -0,0 +1,32 @@
+package types
+
+// fungus.go - Underground fungus gardens
+// Leafcutter-style farming: workers carry cut grass down into a garden
+// chamber, where fungus breaks it down into more food than the grass was
+// worth raw. The garden needs regular tending and dies if neglected or flooded.
+
+// FungusGarden is a colony's fungus farm
+type FungusGarden struct {
+	Position   Position // The garden chamber
+	Vegetation int      // Cut grass waiting to be broken down, in food units
+	Grown      int      // Lifetime food units the garden has produced
+	LastTended int      // Tick the garden was last tended
+	TenderID   int      // ID of the ant on its way to tend the garden, -1 if none
+}
+
+// NewFungusGarden creates an empty garden at the given position, counted as
+// freshly tended so it is not neglected the moment it is founded
+func NewFungusGarden(x, y int, tick int) *FungusGarden {
+	return &FungusGarden{
+		Position:   Position{X: x, Y: y},
+		Vegetation: 0,
+		Grown:      0,
+		LastTended: tick,
+		TenderID:   -1,
+	}
+}
+
+// GetIcon returns the display icon for the garden
+func (g *FungusGarden) GetIcon() rune {
+	return '♣'
+}
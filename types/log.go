@@ -23,3 +23,20 @@ func (a *Ant) GetActivityString(worldNum int) string {
 	return fmt.Sprintf("%s_%s_Ant_%d is currently at (%d,%d) in World_%d",
 		a.ColonyID, roleName[a.Role], a.ID, a.Position.X, a.Position.Y, worldNum)
 }
+
+// MaxEvents is how many events the world keeps before dropping the oldest
+const MaxEvents = 50
+
+// Event is something notable that happened, such as a kill in a fight
+type Event struct {
+	Tick int
+	Text string
+}
+
+// ReportEvent adds an event at the current tick to the world's log
+func (w *World) ReportEvent(format string, args ...any) {
+	w.Events = append(w.Events, Event{Tick: w.Ticks, Text: fmt.Sprintf(format, args...)})
+	if len(w.Events) > MaxEvents {
+		w.Events = w.Events[len(w.Events)-MaxEvents:]
+	}
+}
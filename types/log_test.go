@@ -34,3 +34,27 @@ func TestGetActivityStringDifferentRoles(t *testing.T) {
 		}
 	}
 }
+
+func TestReportEvent(t *testing.T) {
+	world := &World{Ticks: 7}
+	world.ReportEvent("%s killed %s", "Red Soldier 1", "Blue Worker 2")
+
+	if len(world.Events) != 1 || world.Events[0] != (Event{Tick: 7, Text: "Red Soldier 1 killed Blue Worker 2"}) {
+		t.Errorf("Unexpected events %v", world.Events)
+	}
+}
+
+func TestReportEventDropsOldest(t *testing.T) {
+	world := &World{}
+	for i := 0; i < MaxEvents+5; i++ {
+		world.Ticks = i
+		world.ReportEvent("event %d", i)
+	}
+
+	if len(world.Events) != MaxEvents {
+		t.Fatalf("Expected %d events, got %d", MaxEvents, len(world.Events))
+	}
+	if world.Events[0].Tick != 5 {
+		t.Errorf("Oldest events should be dropped first, first is from tick %d", world.Events[0].Tick)
+	}
+}
@@ -9,6 +9,7 @@ type NurseAnt struct {
 	CurrentlyNursing *LarvaeAnt // The larvae this nurse is currently tending
 	NursingSpeed     int        // How fast this nurse helps larvae grow (1-10)
 	LarvaeNursed     int        // Lifetime count of larvae successfully raised
+	FoodAmount       int        // Food ration carried from the stores to a larvae
 }
 
 // NewNurse creates a new nurse ant at the specified position
@@ -16,11 +17,26 @@ func NewNurse(id int, x, y int, colonyID string) *NurseAnt {
 	ant := NewAnt(id, Nurse, x, y, colonyID, NurseMaxHealth, NurseMaxTick)
 	ant.Health = 100
 
+	return AsNurse(ant)
+}
+
+// AsNurse puts an ant to work as a nurse, keeping its ID, age and history
+// An ant that has nursed before keeps its count of larvae raised, but comes
+// back to no larvae in particular
+func AsNurse(ant *Ant) *NurseAnt {
+	ant.Role = Nurse
+	ant.Task = Nursing
+	if nurse, ok := ant.resume(Nurse).(*NurseAnt); ok {
+		nurse.CurrentlyNursing = nil
+		return nurse
+	}
+
 	return &NurseAnt{
 		Ant:              ant,
 		CurrentlyNursing: nil,
 		NursingSpeed:     1,
 		LarvaeNursed:     0,
+		FoodAmount:       0,
 	}
 }
 
@@ -0,0 +1,201 @@
+package types
+
+// pheromone.go - Chemical signals laid over the world grid
+// Each colony has its own field for each kind of pheromone, one integer
+// concentration per cell laid out row-major like World.Cells. Fields
+// evaporate and spread to their neighbours a little every tick. Everything is
+// integer maths with a fixed neighbour order, so the same deposits always
+// leave the same fields.
+
+// PheromoneType identifies what a pheromone signals
+type PheromoneType int
+
+const (
+	Trail             PheromoneType = iota // Recruitment trail laid by foragers coming home with food
+	Alarm                                  // Danger signal, spreads fast and fades fast
+	Territory                              // Colony mark on the ground it uses
+	NumPheromoneTypes                      // Number of pheromone types, for sizing fields
+)
+
+// MaxPheromone is the highest concentration a single cell can hold
+const MaxPheromone = 1000
+
+// pheromoneNeighbours are the offsets a gradient is sensed over, in the order
+// ties are broken: orthogonal first, then diagonal
+var pheromoneNeighbours = [8][2]int{
+	{0, -1}, {0, 1}, {-1, 0}, {1, 0},
+	{-1, -1}, {1, -1}, {-1, 1}, {1, 1},
+}
+
+// PheromoneField is one colony's concentration of one pheromone in every cell
+type PheromoneField struct {
+	Levels []int // Concentration per cell, indexed like World.Cells
+	active bool  // False once every cell has evaporated to zero, so steps can skip it
+}
+
+// PheromoneMap holds every colony's pheromone fields for one world
+// Colonies get their fields the first time they lay anything
+type PheromoneMap struct {
+	Width, Height int
+	Colonies      []string                             // Colony owning each slot in Fields
+	Fields        [][NumPheromoneTypes]*PheromoneField // Fields per colony slot, per type
+	scratch       []int                                // Reused buffer for diffusion
+}
+
+// NewPheromoneMap creates an empty pheromone map for a world of the given size
+func NewPheromoneMap(width, height int) *PheromoneMap {
+	return &PheromoneMap{
+		Width:    width,
+		Height:   height,
+		Colonies: []string{},
+		Fields:   [][NumPheromoneTypes]*PheromoneField{},
+		scratch:  make([]int, width*height),
+	}
+}
+
+// field returns a colony's field for one pheromone, making the colony's
+// fields if create is set and it has none yet. Returns nil otherwise
+func (p *PheromoneMap) field(colonyID string, kind PheromoneType, create bool) *PheromoneField {
+	for i, name := range p.Colonies {
+		if name == colonyID {
+			return p.Fields[i][kind]
+		}
+	}
+	if !create {
+		return nil
+	}
+
+	var fields [NumPheromoneTypes]*PheromoneField
+	for k := range fields {
+		fields[k] = &PheromoneField{Levels: make([]int, p.Width*p.Height)}
+	}
+	p.Colonies = append(p.Colonies, colonyID)
+	p.Fields = append(p.Fields, fields)
+	return fields[kind]
+}
+
+// inBounds checks if a position is inside the map
+func (p *PheromoneMap) inBounds(x, y int) bool {
+	return x >= 0 && x < p.Width && y >= 0 && y < p.Height
+}
+
+// Deposit adds pheromone to a cell, up to MaxPheromone
+func (p *PheromoneMap) Deposit(colonyID string, kind PheromoneType, x, y, amount int) {
+	if !p.inBounds(x, y) || amount <= 0 {
+		return
+	}
+	f := p.field(colonyID, kind, true)
+	i := y*p.Width + x
+	f.Levels[i] += amount
+	if f.Levels[i] > MaxPheromone {
+		f.Levels[i] = MaxPheromone
+	}
+	f.active = true
+}
+
+// Level returns a colony's concentration of one pheromone in a cell
+func (p *PheromoneMap) Level(colonyID string, kind PheromoneType, x, y int) int {
+	if !p.inBounds(x, y) {
+		return 0
+	}
+	f := p.field(colonyID, kind, false)
+	if f == nil {
+		return 0
+	}
+	return f.Levels[y*p.Width+x]
+}
+
+// Levels returns a colony's whole field for one pheromone, indexed like
+// World.Cells, or nil if the colony has never laid any. The slice is the
+// field itself, so callers must not change it
+func (p *PheromoneMap) Levels(colonyID string, kind PheromoneType) []int {
+	f := p.field(colonyID, kind, false)
+	if f == nil {
+		return nil
+	}
+	return f.Levels
+}
+
+// Strongest senses the gradient around a cell and returns the neighbouring
+// position with the most pheromone and its level. Ties go to the first
+// neighbour in orthogonal-then-diagonal order. Returns the cell itself and 0
+// if there is no pheromone around it
+func (p *PheromoneMap) Strongest(colonyID string, kind PheromoneType, x, y int) (Position, int) {
+	best := Position{X: x, Y: y}
+	bestLevel := 0
+	f := p.field(colonyID, kind, false)
+	if f == nil {
+		return best, 0
+	}
+
+	for _, offset := range pheromoneNeighbours {
+		nx, ny := x+offset[0], y+offset[1]
+		if !p.inBounds(nx, ny) {
+			continue
+		}
+		if level := f.Levels[ny*p.Width+nx]; level > bestLevel {
+			best = Position{X: nx, Y: ny}
+			bestLevel = level
+		}
+	}
+	return best, bestLevel
+}
+
+// Step evaporates and diffuses every colony's field of one pheromone by one tick
+// Both rates are per thousand. Evaporation rounds up, so a field always fades
+// to nothing in the end. Diffusion hands a share of each cell out evenly to its
+// four orthogonal neighbours; the share that would fall off the map stays put.
+func (p *PheromoneMap) Step(kind PheromoneType, evaporation, diffusion int) {
+	for _, fields := range p.Fields {
+		f := fields[kind]
+		if !f.active {
+			continue
+		}
+
+		// Evaporate
+		active := false
+		for i, level := range f.Levels {
+			if level == 0 {
+				continue
+			}
+			level -= (level*evaporation + 999) / 1000
+			if level < 0 {
+				level = 0
+			}
+			f.Levels[i] = level
+			if level > 0 {
+				active = true
+			}
+		}
+		f.active = active
+		if !active || diffusion <= 0 {
+			continue
+		}
+
+		// Diffuse into the scratch buffer, then swap the result back in
+		copy(p.scratch, f.Levels)
+		for y := 0; y < p.Height; y++ {
+			for x := 0; x < p.Width; x++ {
+				i := y*p.Width + x
+				share := f.Levels[i] * diffusion / 1000 / 4
+				if share == 0 {
+					continue
+				}
+				for _, offset := range pheromoneNeighbours[:4] {
+					nx, ny := x+offset[0], y+offset[1]
+					if !p.inBounds(nx, ny) {
+						continue
+					}
+					p.scratch[i] -= share
+					p.scratch[ny*p.Width+nx] += share
+				}
+			}
+		}
+		for i, level := range p.scratch {
+			if level > MaxPheromone {
+				level = MaxPheromone
+			}
+			f.Levels[i] = level
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package types
+
+import "testing"
+
+func TestPheromoneDepositAndLevel(t *testing.T) {
+	p := NewPheromoneMap(10, 10)
+
+	p.Deposit("Red", Trail, 3, 4, 50)
+	p.Deposit("Red", Trail, 3, 4, 25)
+
+	if got := p.Level("Red", Trail, 3, 4); got != 75 {
+		t.Errorf("Expected level 75, got %d", got)
+	}
+	if got := p.Level("Red", Alarm, 3, 4); got != 0 {
+		t.Errorf("Pheromone types should be separate, got alarm %d", got)
+	}
+	if got := p.Level("Blue", Trail, 3, 4); got != 0 {
+		t.Errorf("Colonies should be separate, got Blue trail %d", got)
+	}
+}
+
+func TestPheromoneDepositIsCapped(t *testing.T) {
+	p := NewPheromoneMap(10, 10)
+
+	p.Deposit("Red", Trail, 3, 4, MaxPheromone+500)
+
+	if got := p.Level("Red", Trail, 3, 4); got != MaxPheromone {
+		t.Errorf("Expected level capped at %d, got %d", MaxPheromone, got)
+	}
+}
+
+func TestPheromoneOutOfBounds(t *testing.T) {
+	p := NewPheromoneMap(10, 10)
+
+	p.Deposit("Red", Trail, -1, 4, 50)
+
+	if got := p.Level("Red", Trail, -1, 4); got != 0 {
+		t.Errorf("Expected 0 outside the map, got %d", got)
+	}
+}
+
+func TestPheromoneEvaporatesToNothing(t *testing.T) {
+	p := NewPheromoneMap(10, 10)
+	p.Deposit("Red", Trail, 3, 4, 100)
+
+	p.Step(Trail, 100, 0)
+	if got := p.Level("Red", Trail, 3, 4); got != 90 {
+		t.Errorf("Expected 90 after one step, got %d", got)
+	}
+
+	for i := 0; i < 100; i++ {
+		p.Step(Trail, 100, 0)
+	}
+	if got := p.Level("Red", Trail, 3, 4); got != 0 {
+		t.Errorf("Expected trail to evaporate completely, got %d", got)
+	}
+}
+
+func TestPheromoneDiffusesToNeighbours(t *testing.T) {
+	p := NewPheromoneMap(10, 10)
+	p.Deposit("Red", Trail, 5, 5, 400)
+
+	p.Step(Trail, 0, 400)
+
+	if got := p.Level("Red", Trail, 5, 5); got != 240 {
+		t.Errorf("Expected 240 left in the centre, got %d", got)
+	}
+	for _, n := range [][2]int{{5, 4}, {5, 6}, {4, 5}, {6, 5}} {
+		if got := p.Level("Red", Trail, n[0], n[1]); got != 40 {
+			t.Errorf("Expected 40 at (%d,%d), got %d", n[0], n[1], got)
+		}
+	}
+	if got := p.Level("Red", Trail, 4, 4); got != 0 {
+		t.Errorf("Diffusion should only reach orthogonal neighbours, got %d on the diagonal", got)
+	}
+}
+
+func TestPheromoneDiffusionKeepsEdgeShare(t *testing.T) {
+	p := NewPheromoneMap(10, 10)
+	p.Deposit("Red", Trail, 0, 0, 400)
+
+	p.Step(Trail, 0, 400)
+
+	total := p.Level("Red", Trail, 0, 0) + p.Level("Red", Trail, 1, 0) + p.Level("Red", Trail, 0, 1)
+	if total != 400 {
+		t.Errorf("Diffusion at the edge should not lose pheromone, got %d total", total)
+	}
+}
+
+func TestPheromoneStrongest(t *testing.T) {
+	p := NewPheromoneMap(10, 10)
+
+	if pos, level := p.Strongest("Red", Trail, 5, 5); level != 0 || pos != (Position{5, 5}) {
+		t.Errorf("Expected no gradient, got (%d,%d) level %d", pos.X, pos.Y, level)
+	}
+
+	p.Deposit("Red", Trail, 6, 5, 30)
+	p.Deposit("Red", Trail, 4, 4, 80)
+	p.Deposit("Red", Trail, 5, 5, 500) // The cell itself does not count
+
+	pos, level := p.Strongest("Red", Trail, 5, 5)
+	if pos != (Position{4, 4}) || level != 80 {
+		t.Errorf("Expected strongest at (4,4) level 80, got (%d,%d) level %d", pos.X, pos.Y, level)
+	}
+}
+
+func TestPheromoneStrongestTieBreak(t *testing.T) {
+	p := NewPheromoneMap(10, 10)
+	p.Deposit("Red", Trail, 6, 6, 50) // Diagonal
+	p.Deposit("Red", Trail, 5, 6, 50) // Below
+
+	pos, _ := p.Strongest("Red", Trail, 5, 5)
+	if pos != (Position{5, 6}) {
+		t.Errorf("Ties should go to the orthogonal neighbour, got (%d,%d)", pos.X, pos.Y)
+	}
+}
+
+func TestPheromoneLevels(t *testing.T) {
+	p := NewPheromoneMap(10, 10)
+	if p.Levels("Red", Territory) != nil {
+		t.Error("A colony that has laid nothing should have no field")
+	}
+
+	p.Deposit("Red", Territory, 3, 4, 40)
+	levels := p.Levels("Red", Territory)
+	if len(levels) != 100 {
+		t.Fatalf("Expected a level for every cell, got %d", len(levels))
+	}
+	if levels[4*10+3] != 40 {
+		t.Errorf("Expected 40 at (3,4), got %d", levels[4*10+3])
+	}
+}
@@ -0,0 +1,36 @@
+package types
+
+// route.go - Routes the ants of one world are following
+// The pathfinder plans routes, but they are kept with the world they were
+// planned through: a route only makes sense on the grid and clock it was
+// planned against, and two worlds run side by side must never see each
+// other's routes.
+
+// Route is one ant's planned way to a goal
+type Route struct {
+	Goal  Position
+	Steps []Position // Cells still to walk, ending with Goal
+	Digs  int        // World.TunnelEdits when the route was last checked against the edits
+	Used  int        // Tick the route was last followed
+}
+
+// Routes holds every ant's route in one world, and the scratch space for
+// planning them. The scratch grids are reused by every search, and a cell's
+// Cost and From only count while its Seen matches Searches, so nothing has
+// to be cleared between searches
+type Routes struct {
+	ByAnt     map[*Ant]*Route
+	LastSweep int   // Tick forgotten routes were last cleared out
+	Searches  int   // Searches run so far
+	Seen      []int // Search that last reached each cell
+	Cost      []int // Cheapest known cost to each cell
+	From      []int // Cell each was reached from
+}
+
+// NewRoutes creates an empty set of routes
+func NewRoutes() *Routes {
+	return &Routes{
+		ByAnt:     map[*Ant]*Route{},
+		LastSweep: 0,
+	}
+}
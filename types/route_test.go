@@ -0,0 +1,17 @@
+package types
+
+import (
+	"antfarm/random"
+	"testing"
+)
+
+func TestNewWorldHasNoRoutes(t *testing.T) {
+	world := NewWorld(10, 10, random.New(1))
+
+	if world.Routes == nil || len(world.Routes.ByAnt) != 0 {
+		t.Error("A new world should have an empty set of routes")
+	}
+	if other := NewWorld(10, 10, random.New(1)); other.Routes == world.Routes {
+		t.Error("Worlds should not share their routes")
+	}
+}
@@ -10,6 +10,7 @@ type SoldierAnt struct {
 	DefenseBonus   int       // Damage reduction
 	IsPatrolling   bool      // Is this soldier on patrol duty?
 	TargetPosition *Position // Where the soldier is heading
+	PatrolTimer    int       // Ticks left on patrol before a rest, or of the rest
 }
 
 // NewSoldier creates a new soldier ant at the specified position
@@ -17,12 +18,28 @@ func NewSoldier(id int, x, y int, colonyID string) *SoldierAnt {
 	ant := NewAnt(id, Soldier, x, y, colonyID, SoldierMaxHealth, SoldierMaxTick)
 	ant.Health = 150 // Soldiers have more health than workers
 
+	return AsSoldier(ant)
+}
+
+// AsSoldier puts an ant to work as a soldier, keeping its ID, age and history
+// An ant that has been a soldier before keeps its patrol timer, but is no
+// longer out on patrol
+func AsSoldier(ant *Ant) *SoldierAnt {
+	ant.Role = Soldier
+	ant.Task = Guarding
+	if soldier, ok := ant.resume(Soldier).(*SoldierAnt); ok {
+		soldier.IsPatrolling = false
+		soldier.TargetPosition = nil
+		return soldier
+	}
+
 	return &SoldierAnt{
 		Ant:            ant,
 		AttackPower:    20,
 		DefenseBonus:   10,
 		IsPatrolling:   false,
 		TargetPosition: nil,
+		PatrolTimer:    0,
 	}
 }
 
@@ -0,0 +1,61 @@
+package types
+
+// task.go - The jobs adult ants do for the colony
+// An adult's role decides how it behaves, and its task is the job it has
+// taken on within that. Nursing is done by nurses, guarding by soldiers, and
+// foraging and digging by workers. Ants can take up a new task as they age or
+// as the colony's needs change, which changes their role with it.
+
+// Task is a job an adult ant can do for the colony
+type Task int
+
+const (
+	NoTask   Task = iota // Queens and larvae
+	Nursing              // Raising larvae
+	Foraging             // Bringing in food
+	Digging              // Extending the nest
+	Guarding             // Patrolling and fighting
+	NumTasks             // How many tasks there are, for sizing arrays
+)
+
+// String returns the task's name
+func (t Task) String() string {
+	switch t {
+	case Nursing:
+		return "nursing"
+	case Foraging:
+		return "foraging"
+	case Digging:
+		return "digging"
+	case Guarding:
+		return "guarding"
+	default:
+		return "none"
+	}
+}
+
+// Role returns the role that does a task
+func (t Task) Role() Role {
+	switch t {
+	case Nursing:
+		return Nurse
+	case Guarding:
+		return Soldier
+	default:
+		return Worker
+	}
+}
+
+// taskFor returns the task an ant of a role starts out on
+func taskFor(role Role) Task {
+	switch role {
+	case Worker:
+		return Foraging
+	case Soldier:
+		return Guarding
+	case Nurse:
+		return Nursing
+	default:
+		return NoTask
+	}
+}
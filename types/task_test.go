@@ -0,0 +1,83 @@
+package types
+
+import "testing"
+
+func TestTaskRole(t *testing.T) {
+	tests := []struct {
+		task Task
+		role Role
+	}{
+		{Nursing, Nurse},
+		{Foraging, Worker},
+		{Digging, Worker},
+		{Guarding, Soldier},
+	}
+
+	for _, tt := range tests {
+		if got := tt.task.Role(); got != tt.role {
+			t.Errorf("%s.Role() = %s, expected %s", tt.task, got, tt.role)
+		}
+	}
+}
+
+func TestNewAntsStartOnTheirRolesTask(t *testing.T) {
+	if task := NewWorker(1, 0, 0, "Red").Task; task != Foraging {
+		t.Errorf("Expected a new worker to forage, got %s", task)
+	}
+	if task := NewNurse(1, 0, 0, "Red").Task; task != Nursing {
+		t.Errorf("Expected a new nurse to nurse, got %s", task)
+	}
+	if task := NewSoldier(1, 0, 0, "Red").Task; task != Guarding {
+		t.Errorf("Expected a new soldier to guard, got %s", task)
+	}
+	if task := NewQueen(1, 0, 0, "Red").Task; task != NoTask {
+		t.Errorf("Expected a queen to have no task, got %s", task)
+	}
+}
+
+func TestAsRoleKeepsTheAnt(t *testing.T) {
+	worker := NewWorker(7, 3, 4, "Red")
+	worker.Age = 99
+	worker.Task = Digging
+
+	soldier := AsSoldier(worker.Ant)
+	if soldier.Ant != worker.Ant || soldier.ID != 7 || soldier.Age != 99 {
+		t.Error("AsSoldier should wrap the same ant")
+	}
+	if soldier.Role != Soldier || soldier.Task != Guarding {
+		t.Errorf("Expected a guarding soldier, got %s on %s", soldier.Role, soldier.Task)
+	}
+
+	back := AsWorker(soldier.Ant)
+	if back.Role != Worker || back.Task != Foraging {
+		t.Errorf("Expected a foraging worker, got %s on %s", back.Role, back.Task)
+	}
+}
+
+func TestAsRoleResumesFormerRole(t *testing.T) {
+	worker := NewWorker(7, 3, 4, "Red")
+	worker.Deposits = 5
+	target := Position{X: 9, Y: 9}
+	worker.TargetPosition = &target
+
+	worker.Leave(worker)
+	soldier := AsSoldier(worker.Ant)
+	soldier.PatrolTimer = 40
+	soldier.IsPatrolling = true
+	soldier.Leave(soldier)
+
+	back := AsWorker(soldier.Ant)
+	if back != worker || back.Deposits != 5 {
+		t.Error("AsWorker should take up the worker the ant was before")
+	}
+	if back.TargetPosition != nil {
+		t.Error("A resumed worker should not still be headed anywhere")
+	}
+	again := AsSoldier(back.Ant)
+	if again != soldier || again.PatrolTimer != 40 || again.IsPatrolling {
+		t.Error("AsSoldier should take up the soldier the ant was before, off patrol")
+	}
+	if fresh := AsNurse(again.Ant); fresh.LarvaeNursed != 0 {
+		t.Error("An ant that never nursed should start as a fresh nurse")
+	}
+}
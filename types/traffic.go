@@ -0,0 +1,61 @@
+package types
+
+// traffic.go - Moves declared during a tick and the jams they run into
+// While a tick's moves are pending, an ant that steps somewhere only declares
+// where it wants to go. Once every ant has had its turn the moves are settled
+// all at once, so no ant wins a cell just by being updated first. Every move
+// that loses out is counted against the cell it was trying to get into, which
+// shows where the nest is congested.
+
+// MoveIntent is the step one ant has declared for this tick
+type MoveIntent struct {
+	Ant  AntInterface
+	From Position // Where the ant stood when it declared the move
+	To   Position
+}
+
+// Traffic holds the moves declared this tick
+type Traffic struct {
+	Pending bool         // Moves are being declared rather than made
+	Intents []MoveIntent // In the order they were first declared
+	Jams    []int        // Moves turned back per cell, indexed like World.Cells
+	byAnt   map[*Ant]int // Index in Intents of each ant's move
+}
+
+// NewTraffic creates empty traffic for a world of the given size
+func NewTraffic(width, height int) *Traffic {
+	return &Traffic{
+		Pending: false,
+		Intents: nil,
+		Jams:    make([]int, width*height),
+		byAnt:   map[*Ant]int{},
+	}
+}
+
+// Declare records where an ant wants to step, replacing any earlier move it
+// declared this tick
+func (t *Traffic) Declare(ant AntInterface, to Position) {
+	base := ant.GetAnt()
+	if i, ok := t.byAnt[base]; ok {
+		t.Intents[i].To = to
+		return
+	}
+	t.byAnt[base] = len(t.Intents)
+	t.Intents = append(t.Intents, MoveIntent{Ant: ant, From: base.Position, To: to})
+}
+
+// Take hands over the declared moves and clears them for the next tick
+func (t *Traffic) Take() []MoveIntent {
+	intents := t.Intents
+	t.Intents = nil
+	clear(t.byAnt)
+	return intents
+}
+
+// JamsAt returns how many moves into a cell have been turned back
+func (w *World) JamsAt(x, y int) int {
+	if w.Traffic == nil || !w.IsValidPosition(x, y) {
+		return 0
+	}
+	return w.Traffic.Jams[w.Index(x, y)]
+}
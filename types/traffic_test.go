@@ -0,0 +1,42 @@
+package types
+
+import (
+	"antfarm/random"
+	"testing"
+)
+
+func TestTrafficDeclareReplacesEarlierMove(t *testing.T) {
+	traffic := NewTraffic(10, 10)
+	worker := NewWorker(1, 3, 3, "Red")
+
+	traffic.Declare(worker, Position{X: 4, Y: 3})
+	traffic.Declare(worker, Position{X: 3, Y: 4})
+
+	if len(traffic.Intents) != 1 {
+		t.Fatalf("An ant should have one move per tick, got %d", len(traffic.Intents))
+	}
+	move := traffic.Intents[0]
+	if move.From != (Position{X: 3, Y: 3}) || move.To != (Position{X: 3, Y: 4}) {
+		t.Errorf("Expected (3,3) to (3,4), got %v to %v", move.From, move.To)
+	}
+
+	if taken := traffic.Take(); len(taken) != 1 || len(traffic.Intents) != 0 {
+		t.Error("Take should hand over the moves and clear them")
+	}
+	traffic.Declare(worker, Position{X: 2, Y: 3})
+	if len(traffic.Intents) != 1 || traffic.Intents[0].To != (Position{X: 2, Y: 3}) {
+		t.Error("A new tick should start with no moves")
+	}
+}
+
+func TestWorldJamsAt(t *testing.T) {
+	world := NewWorld(10, 10, random.New(1))
+	world.Traffic.Jams[world.Index(4, 5)] = 3
+
+	if got := world.JamsAt(4, 5); got != 3 {
+		t.Errorf("Expected 3 jams, got %d", got)
+	}
+	if got := world.JamsAt(-1, 5); got != 0 {
+		t.Errorf("Outside the world should have no jams, got %d", got)
+	}
+}
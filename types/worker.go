@@ -3,14 +3,32 @@ package types
 // worker.go
 // Workers dig tunnels, gather food, and expand the colony
 
+// FoodMemorySize is how many food sites a worker can remember at once
+const FoodMemorySize = 3
+
+// FoodSite is a place a worker found food and left some behind
+type FoodSite struct {
+	Position  Position
+	Freshness int // Ticks left before the worker forgets it
+}
+
 // Worker represents a laboring ant that digs and forages
 type WorkerAnt struct {
 	*Ant
-	CarryingFood     bool      // Is this worker carrying food?
-	FoodAmount       int       // How much food is being carried
-	DiggingPower     int       // How fast this worker digs (1-10)
-	TargetPosition   *Position // Where the worker is trying to go
-	CurrentDirection int       // Current movement direction
+	CarryingFood     bool       // Is this worker carrying food?
+	FoodAmount       int        // How much food is being carried
+	FoodType         FoodType   // What kind of food is being carried
+	CarryingGrass    bool       // Cargo is cut grass for the fungus garden
+	CarryingCorpse   bool       // Carrying a dead nestmate out to the midden
+	Hauling          int        // Food being carried to where the stores are moving
+	Deposits         int        // Loads of food this worker has delivered to the stores or garden
+	DiggingPower     int        // How fast this worker digs (1-10)
+	TargetPosition   *Position  // Where the worker is trying to go
+	HomeVector       Position   // Where the worker reckons home is, relative to itself
+	Travelled        int        // Cells walked since the worker last knew exactly where home was
+	Integrated       Position   // Where the worker was when its home vector was last brought up to date
+	FoodSites        []FoodSite // Places food was still left on the last visit, freshest first
+	CurrentDirection int        // Current movement direction
 	MovesInDirection int
 	MovesMade        int
 }
@@ -20,18 +38,87 @@ func NewWorker(id int, x, y int, colonyID string) *WorkerAnt {
 	ant := NewAnt(id, Worker, x, y, colonyID, WorkerMaxHealth, WorkerMaxTick)
 	ant.Health = 100
 
+	return AsWorker(ant)
+}
+
+// AsWorker puts an ant to work as a worker, keeping its ID, age and history
+// An ant that has been a worker before picks up where it left off, with its
+// deliveries, home vector and food sites, though no longer headed anywhere
+func AsWorker(ant *Ant) *WorkerAnt {
+	ant.Role = Worker
+	if ant.Task != Foraging && ant.Task != Digging {
+		ant.Task = Foraging
+	}
+	if worker, ok := ant.resume(Worker).(*WorkerAnt); ok {
+		worker.TargetPosition = nil
+		return worker
+	}
+
 	return &WorkerAnt{
 		Ant:              ant,
 		CarryingFood:     false,
 		FoodAmount:       0,
+		FoodType:         Carbohydrate,
+		CarryingGrass:    false,
+		CarryingCorpse:   false,
+		Hauling:          0,
+		Deposits:         0,
 		DiggingPower:     1,
 		TargetPosition:   nil,
+		HomeVector:       Position{X: 0, Y: 0},
+		Travelled:        0,
+		Integrated:       ant.Position,
+		FoodSites:        nil,
 		CurrentDirection: 0,
 		MovesInDirection: 0,
 		MovesMade:        0,
 	}
 }
 
+// SetHome fixes the worker's home vector on a home position it can see
+func (w *WorkerAnt) SetHome(home Position) {
+	w.HomeVector = Position{X: home.X - w.Position.X, Y: home.Y - w.Position.Y}
+	w.Travelled = 0
+	w.Integrated = w.Position
+}
+
+// ReckonedHome returns where the worker believes home is
+func (w *WorkerAnt) ReckonedHome() Position {
+	return Position{X: w.Position.X + w.HomeVector.X, Y: w.Position.Y + w.HomeVector.Y}
+}
+
+// RememberFood remembers a food site, or refreshes it if already known
+// The site goes to the front. If memory is full the stalest site is forgotten
+func (w *WorkerAnt) RememberFood(pos Position, freshness int) {
+	w.ForgetFood(pos)
+	w.FoodSites = append([]FoodSite{{Position: pos, Freshness: freshness}}, w.FoodSites...)
+	if len(w.FoodSites) > FoodMemorySize {
+		w.FoodSites = w.FoodSites[:FoodMemorySize]
+	}
+}
+
+// ForgetFood forgets the food site at a position, if the worker knows one
+func (w *WorkerAnt) ForgetFood(pos Position) {
+	for i, site := range w.FoodSites {
+		if site.Position == pos {
+			w.FoodSites = append(w.FoodSites[:i], w.FoodSites[i+1:]...)
+			return
+		}
+	}
+}
+
+// FadeFoodMemory ages every remembered site by a tick and forgets those gone stale
+func (w *WorkerAnt) FadeFoodMemory() {
+	kept := w.FoodSites[:0]
+	for _, site := range w.FoodSites {
+		site.Freshness--
+		if site.Freshness > 0 {
+			kept = append(kept, site)
+		}
+	}
+	w.FoodSites = kept
+}
+
 // GetAnt returns the Ant
 func (w *WorkerAnt) GetAnt() *Ant {
 	return w.Ant
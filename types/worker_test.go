@@ -45,3 +45,64 @@ func TestWorkerGetRole(t *testing.T) {
 		t.Errorf("Expected Worker role, got %d", worker.GetRole())
 	}
 }
+
+func TestWorkerSetHome(t *testing.T) {
+	worker := NewWorker(1, 4, 7, "Red")
+	worker.Travelled = 30
+
+	worker.SetHome(Position{X: 10, Y: 5})
+
+	if worker.HomeVector != (Position{X: 6, Y: -2}) {
+		t.Errorf("Expected home vector (6,-2), got (%d,%d)", worker.HomeVector.X, worker.HomeVector.Y)
+	}
+	if worker.Travelled != 0 {
+		t.Errorf("Seeing home should clear the distance travelled, got %d", worker.Travelled)
+	}
+	if home := worker.ReckonedHome(); home != (Position{X: 10, Y: 5}) {
+		t.Errorf("Expected to reckon home at (10,5), got (%d,%d)", home.X, home.Y)
+	}
+}
+
+func TestWorkerRememberFood(t *testing.T) {
+	worker := NewWorker(1, 0, 0, "Red")
+	for x := 1; x <= FoodMemorySize+1; x++ {
+		worker.RememberFood(Position{X: x, Y: 5}, 100)
+	}
+
+	if len(worker.FoodSites) != FoodMemorySize {
+		t.Fatalf("Expected memory to hold %d sites, got %d", FoodMemorySize, len(worker.FoodSites))
+	}
+	if worker.FoodSites[0].Position.X != FoodMemorySize+1 {
+		t.Errorf("Newest site should come first, got %v", worker.FoodSites[0].Position)
+	}
+	for _, site := range worker.FoodSites {
+		if site.Position.X == 1 {
+			t.Error("The stalest site should have been forgotten")
+		}
+	}
+
+	worker.RememberFood(Position{X: 2, Y: 5}, 100)
+	if worker.FoodSites[0].Position.X != 2 || len(worker.FoodSites) != FoodMemorySize {
+		t.Errorf("Revisiting a site should move it to the front without a duplicate, got %v", worker.FoodSites)
+	}
+}
+
+func TestWorkerFoodMemoryFades(t *testing.T) {
+	worker := NewWorker(1, 0, 0, "Red")
+	worker.RememberFood(Position{X: 1, Y: 1}, 1)
+	worker.RememberFood(Position{X: 2, Y: 2}, 3)
+
+	worker.FadeFoodMemory()
+
+	if len(worker.FoodSites) != 1 || worker.FoodSites[0].Position != (Position{X: 2, Y: 2}) {
+		t.Fatalf("Only the fresher site should be left, got %v", worker.FoodSites)
+	}
+	if worker.FoodSites[0].Freshness != 2 {
+		t.Errorf("Expected freshness 2, got %d", worker.FoodSites[0].Freshness)
+	}
+
+	worker.ForgetFood(Position{X: 2, Y: 2})
+	if len(worker.FoodSites) != 0 {
+		t.Errorf("Forgotten site should be gone, got %v", worker.FoodSites)
+	}
+}
@@ -2,18 +2,32 @@ package types
 
 import "antfarm/random"
 
+// editLogSize is how many of the latest tunnel edits the world remembers
+const editLogSize = 256
+
 // world.go - Defines the game world (the entire ant farm environment)
 // The world is a 2D grid of cells containing terrain, tunnels, and ants
 
 // World represents the entire  environment
 // Contains the grid of cells, all colonies, and tracks simulation time
 type World struct {
-	Width    int               // Width of the world
-	Height   int               // Height of the world
-	Cells    []Cell            // Flat grid, row-major: the cell at (x, y) is Cells[y*Width+x]
-	Colonies []*Colony         // All ant colonies in this world
-	Ticks    int               // Number of updates that have occurred
-	Random   *random.Generator // Deterministic random source for the whole simulation
+	Width         int                 // Width of the world
+	Height        int                 // Height of the world
+	Cells         []Cell              // Flat grid, row-major: the cell at (x, y) is Cells[y*Width+x]
+	Colonies      []*Colony           // All ant colonies in this world
+	Critters      []*Critter          // All non-ant creatures in this world
+	Corpses       []*Corpse           // All dead ants still lying in the world
+	Pheromones    *PheromoneMap       // Every colony's pheromone fields over the grid
+	Scent         [NumFoodTypes][]int // Food scent of each type per cell, indexed like Cells
+	Territory     []string            // Name of the colony holding each cell, "" if nobody
+	TunnelEdits   int                 // Tunnels dug or filled so far
+	EditedCells   []int               // Index of each cell dug or filled, the latest editLogSize edits, oldest first
+	Traffic       *Traffic            // Moves declared this tick, and where moves have jammed
+	Routes        *Routes             // Routes ants have planned and are following
+	Events        []Event             // Notable things that have happened, oldest first
+	NextCritterID int                 // Counter for generating unique critter IDs
+	Ticks         int                 // Number of updates that have occurred
+	Random        *random.Generator   // Deterministic random source for the whole simulation
 }
 
 // NewWorld creates a new world with procedurally generated terrain
@@ -29,6 +43,7 @@ func NewWorld(width, height int, r *random.Generator) *World {
 			// Generate terrain
 			soilType := generateSoilType(y, height)
 			cells[y*width+x] = *NewCell(soilType)
+			cells[y*width+x].Moisture = generateMoisture(y, height)
 		}
 	}
 
@@ -41,19 +56,34 @@ func NewWorld(width, height int, r *random.Generator) *World {
 	}
 
 	// Scatter food on surface (top row)
+	// Most pellets are seeds, but some are insect prey the colony needs for protein
 	for x := 0; x < width; x++ {
 		if r.Chance(10) { // 10% chance of food
 			cells[1*width+x].Food = 5 * FoodScale // Food pellet, 5 food
+			if r.Chance(30) {
+				cells[1*width+x].FoodType = Protein
+			}
 		}
 	}
 
 	return &World{
-		Width:    width,
-		Height:   height,
-		Cells:    cells,
-		Colonies: []*Colony{},
-		Ticks:    0,
-		Random:   r,
+		Width:         width,
+		Height:        height,
+		Cells:         cells,
+		Colonies:      []*Colony{},
+		Critters:      []*Critter{},
+		Corpses:       []*Corpse{},
+		Pheromones:    NewPheromoneMap(width, height),
+		Scent:         newScent(width * height),
+		Territory:     make([]string, width*height), // Nobody holds any ground until colonies start marking it
+		TunnelEdits:   0,
+		EditedCells:   []int{},
+		Traffic:       NewTraffic(width, height),
+		Routes:        NewRoutes(),
+		Events:        []Event{},
+		NextCritterID: 0,
+		Ticks:         0,
+		Random:        r,
 	}
 }
 
@@ -84,6 +114,21 @@ func generateSoilType(y, _ int) Soil {
 	return Sand // Everything else is sand
 }
 
+// generateMoisture determines how damp the ground is at a given depth
+// The surface is dry and the soil gets wetter toward the water table at the
+// bottom of the world, where the deepest rows sit flooded
+func generateMoisture(y, maxHeight int) int {
+	if y < 2 || maxHeight <= 0 {
+		return 0 // Surface air
+	}
+	return y * 100 / maxHeight
+}
+
+// BaseMoisture returns how damp the ground at a depth is when left alone
+func (w *World) BaseMoisture(y int) int {
+	return generateMoisture(y, w.Height)
+}
+
 // IsValidPosition checks if the given coordinates are within world bounds
 func (w *World) IsValidPosition(x, y int) bool {
 	return x >= 0 && x < w.Width && y >= 0 && y < w.Height
@@ -108,3 +153,110 @@ func (w *World) GetCell(x, y int) *Cell {
 	}
 	return &w.Cells[w.Index(x, y)]
 }
+
+// Dig opens a tunnel in the given cell, logs it as an edit and patches
+// every colony's home field. Returns false if the position is out of bounds
+// or already open
+func (w *World) Dig(x, y int) bool {
+	cell := w.GetCell(x, y)
+	if cell == nil || cell.IsTunnel {
+		return false
+	}
+	cell.IsTunnel = true
+	w.logEdit(x, y)
+	for _, colony := range w.Colonies {
+		if colony.HomeField != nil {
+			colony.HomeField.Opened(w, x, y)
+		}
+	}
+	return true
+}
+
+// Fill closes a tunnel, as when it caves in, logs it as an edit and patches
+// every colony's home field. Whatever is in the cell is left for the
+// caller to deal with. Returns false if the position is out of bounds or not open
+func (w *World) Fill(x, y int) bool {
+	cell := w.GetCell(x, y)
+	if cell == nil || !cell.IsTunnel {
+		return false
+	}
+	cell.IsTunnel = false
+	w.logEdit(x, y)
+	for _, colony := range w.Colonies {
+		if colony.HomeField != nil {
+			colony.HomeField.Closed(w, x, y)
+		}
+	}
+	return true
+}
+
+// logEdit counts a tunnel edit and remembers which cell it was, forgetting
+// the oldest once the log is full
+func (w *World) logEdit(x, y int) {
+	w.TunnelEdits++
+	w.EditedCells = append(w.EditedCells, w.Index(x, y))
+	if len(w.EditedCells) > editLogSize {
+		w.EditedCells = w.EditedCells[len(w.EditedCells)-editLogSize:]
+	}
+}
+
+// EditsSince returns the cells dug or filled since TunnelEdits was edits
+// Returns false if some of those edits are too old to still be in the log
+func (w *World) EditsSince(edits int) ([]int, bool) {
+	newer := w.TunnelEdits - edits
+	if newer > len(w.EditedCells) {
+		return nil, false
+	}
+	return w.EditedCells[len(w.EditedCells)-newer:], true
+}
+
+// newScent makes an empty scent field for each food type
+func newScent(cells int) [NumFoodTypes][]int {
+	var scent [NumFoodTypes][]int
+	for foodType := range scent {
+		scent[foodType] = make([]int, cells)
+	}
+	return scent
+}
+
+// ScentAt returns how strongly food of any type can be smelled at the given
+// position. Returns 0 if the position is out of bounds
+func (w *World) ScentAt(x, y int) int {
+	return w.ScentOf(x, y, Carbohydrate, Protein, Sugar)
+}
+
+// ScentOf returns the strongest scent of the listed food types at the given
+// position. Returns 0 if the position is out of bounds
+func (w *World) ScentOf(x, y int, diet ...FoodType) int {
+	if !w.IsValidPosition(x, y) {
+		return 0
+	}
+	strongest := 0
+	for _, foodType := range diet {
+		strongest = max(strongest, w.Scent[foodType][w.Index(x, y)])
+	}
+	return strongest
+}
+
+// TerritoryOwner returns the colony holding the given position
+// Returns nil if nobody holds it, the colony that did is gone, or the
+// position is out of bounds
+func (w *World) TerritoryOwner(x, y int) *Colony {
+	if !w.IsValidPosition(x, y) {
+		return nil
+	}
+	return w.ColonyNamed(w.Territory[w.Index(x, y)])
+}
+
+// ColonyNamed returns the colony in the world with the given name, or nil
+func (w *World) ColonyNamed(name string) *Colony {
+	if name == "" {
+		return nil
+	}
+	for _, colony := range w.Colonies {
+		if colony.Name == name {
+			return colony
+		}
+	}
+	return nil
+}
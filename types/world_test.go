@@ -103,3 +103,119 @@ func TestGetCell(t *testing.T) {
 		t.Error("Expected nil for invalid position")
 	}
 }
+
+func TestScentAtOutOfBounds(t *testing.T) {
+	world := NewWorld(10, 10, random.New(1))
+	world.Scent[Protein][world.Index(2, 3)] = 7
+
+	if got := world.ScentAt(2, 3); got != 7 {
+		t.Errorf("Expected scent 7, got %d", got)
+	}
+	if got := world.ScentAt(-1, 3); got != 0 {
+		t.Errorf("Expected 0 outside the world, got %d", got)
+	}
+}
+
+func TestTerritoryOwner(t *testing.T) {
+	world := NewWorld(10, 10, random.New(1))
+	world.Colonies = append(world.Colonies, NewColony("Red", 5, 5, ColonyRed))
+
+	if owner := world.TerritoryOwner(2, 3); owner != nil {
+		t.Errorf("A new world should be unclaimed, got %s", owner.Name)
+	}
+	world.Territory[world.Index(2, 3)] = "Red"
+	if owner := world.TerritoryOwner(2, 3); owner == nil || owner.Name != "Red" {
+		t.Error("Expected Red to hold (2,3)")
+	}
+	if owner := world.TerritoryOwner(-1, 3); owner != nil {
+		t.Error("Expected nil outside the world")
+	}
+}
+
+func TestTerritoryOwnerSurvivesColoniesChanging(t *testing.T) {
+	world := NewWorld(10, 10, random.New(1))
+	red, blue := NewColony("Red", 2, 5, ColonyRed), NewColony("Blue", 7, 5, ColonyBlue)
+	world.Colonies = append(world.Colonies, red, blue)
+	world.Territory[world.Index(7, 3)] = "Blue"
+
+	world.Colonies = []*Colony{blue, red}
+	if owner := world.TerritoryOwner(7, 3); owner != blue {
+		t.Error("Reordering the colonies should not change who holds a cell")
+	}
+	world.Colonies = []*Colony{red}
+	if owner := world.TerritoryOwner(7, 3); owner != nil {
+		t.Errorf("A colony that is gone should hold nothing, got %s", owner.Name)
+	}
+}
+
+func TestWorldDigCountsTunnels(t *testing.T) {
+	world := NewWorld(10, 10, random.New(1))
+
+	if !world.Dig(4, 6) {
+		t.Fatal("Should be able to dig into soil")
+	}
+	if !world.GetCell(4, 6).IsTunnel {
+		t.Error("Dug cell should be open tunnel")
+	}
+	if world.Dig(4, 6) || world.Dig(4, 0) || world.Dig(-1, 6) {
+		t.Error("Open cells and cells outside the world cannot be dug")
+	}
+	if world.TunnelEdits != 1 {
+		t.Errorf("Expected 1 dig counted, got %d", world.TunnelEdits)
+	}
+}
+
+func TestWorldFillClosesTunnels(t *testing.T) {
+	world := NewWorld(10, 10, random.New(1))
+	world.Dig(4, 6)
+
+	if !world.Fill(4, 6) {
+		t.Fatal("Should be able to fill an open tunnel")
+	}
+	if world.GetCell(4, 6).IsTunnel {
+		t.Error("Filled cell should be solid again")
+	}
+	if world.Fill(4, 6) || world.Fill(-1, 6) {
+		t.Error("Solid cells and cells outside the world cannot be filled")
+	}
+	if world.TunnelEdits != 2 {
+		t.Errorf("Expected 2 tunnel edits counted, got %d", world.TunnelEdits)
+	}
+}
+
+func TestEditsSinceListsEditedCells(t *testing.T) {
+	world := NewWorld(10, 10, random.New(1))
+	world.Dig(4, 6)
+	seen := world.TunnelEdits
+	world.Dig(5, 6)
+	world.Fill(4, 6)
+
+	cells, ok := world.EditsSince(seen)
+	if !ok || len(cells) != 2 || cells[0] != world.Index(5, 6) || cells[1] != world.Index(4, 6) {
+		t.Errorf("Expected the two later edits, got %v", cells)
+	}
+	if cells, ok := world.EditsSince(world.TunnelEdits); !ok || len(cells) != 0 {
+		t.Errorf("Expected no edits since now, got %v", cells)
+	}
+
+	for i := 0; i < editLogSize; i++ {
+		world.Dig(i%10, 3+i/10%7)
+		world.Fill(i%10, 3+i/10%7)
+	}
+	if _, ok := world.EditsSince(seen); ok {
+		t.Error("Edits older than the log should not be listed")
+	}
+}
+
+func TestBaseMoistureMatchesGeneratedGround(t *testing.T) {
+	world := NewWorld(20, 30, random.New(1))
+
+	for y := 0; y < world.Height; y++ {
+		if got, want := world.BaseMoisture(y), world.GetCell(5, y).Moisture; got != want {
+			t.Errorf("Row %d: expected base moisture %d, got %d", y, want, got)
+		}
+	}
+	if world.BaseMoisture(25) <= world.BaseMoisture(5) {
+		t.Error("Deeper ground should be damper")
+	}
+}